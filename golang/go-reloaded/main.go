@@ -1,277 +1,228 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strconv"
 	"strings"
-)
-
-// finding a number value from a string eg "2)"" and converts it into an integer
-func getInt(s string) int {
-
-	num := 1
 
-	re := regexp.MustCompile(`\d+`)
-
-	match := re.FindString(s)
-	if match != "" {
-		number, _ := strconv.Atoi(match)
+	"goreloaded/reloaded"
+)
 
-		num = number
+// opens the input file for reading, "-" reads from the standard input
+func openInput(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
 	}
-
-	return num
+	fileLocation, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s file location", name)
+	}
+	return fileLocation, nil
 }
 
-// finding an index of a slice that equals (hex) or (bin) and converts the previous index accordingly. Removes even empty indexes in addition to identifier indexes.
-func fixHexBin(s string) string {
-	slice := strings.Split(s, " ")
-
-	for i := 0; i < len(slice); i++ {
-		if slice[i] == "" {
-			slice = append(slice[:i], slice[i+1:]...)
-			i--
-		} else if slice[i] == "(hex)" || slice[i] == "(bin)" {
-			if i > 0 {
-				indexToConvert := slice[i-1]
-				var decimalValue int64
-				var err error
-
-				if slice[i] == "(hex)" {
-					decimalValue, err = strconv.ParseInt(indexToConvert, 16, 64)
-				} else if slice[i] == "(bin)" {
-					decimalValue, err = strconv.ParseInt(indexToConvert, 2, 64)
-				}
-				if err != nil {
-					fmt.Println("Error converting value:", err)
-					os.Exit(1)
-				}
-				slice[i-1] = strconv.FormatInt(decimalValue, 10)
-				slice = append(slice[:i], slice[i+1:]...)
-				i--
-			}
+// Creates the output, "-" writes to the standard output.
+// The text goes to a temporary file next to the output that finish(true) moves over it, so an existing output is only replaced once the run succeeded
+// and the input isn't wiped before it is read when the output is the input file itself. finish(false) throws the temporary file away and leaves the output as it was.
+// Outputs that aren't regular files, like /dev/null or a pipe, are written directly. In strict mode the text for the standard output is held back until finish(true).
+func createOutput(name string, strict bool) (out io.WriteCloser, finish func(ok bool) error, err error) {
+	done := func(bool) error { return nil }
+	if name == "-" {
+		if !strict {
+			return nopWriteCloser{os.Stdout}, done, nil
 		}
+		held := &bytes.Buffer{}
+		return nopWriteCloser{held}, func(ok bool) error {
+			if !ok {
+				return nil
+			}
+			_, err := held.WriteTo(os.Stdout)
+			return err
+		}, nil
 	}
-	return strings.Join(slice, " ")
-}
 
-// finding an index of a slice that equals "a" or "A" and checks if the following index starts with a vowel. If yes, adding "n" to the end of the index.
-func fixArticle(s string) string {
-	slice := strings.Split(s, " ")
-	var isVowel = map[rune]bool{
-		'a': true,
-		'e': true,
-		'i': true,
-		'o': true,
-		'u': true,
-		'A': true,
-		'E': true,
-		'I': true,
-		'O': true,
-		'U': true,
+	target := name
+	if resolved, err := filepath.EvalSymlinks(name); err == nil {
+		target = resolved // replacing the file the link points to, not the link
 	}
-
-	for i := 0; i < len(slice)-1; i++ {
-		if strings.ToLower(slice[i]) == "a" {
-			nextWord := slice[i+1]
-			if isVowel[rune(nextWord[0])] {
-				slice[i] += "n"
+	mode := os.FileMode(0644) // the mode of a new output
+	if info, err := os.Stat(target); err == nil {
+		if !info.Mode().IsRegular() {
+			textToFile, err := os.OpenFile(target, os.O_WRONLY, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("couldn't open %s", name)
 			}
+			return textToFile, done, nil
 		}
+		mode = info.Mode().Perm()
 	}
-	return strings.Join(slice, " ")
-}
-
-// finding an index of a slice that equals equals case specific commands (cap) (up) (low) for one or various words and converts the previous index(es) accordingly. Removes even empty indexes as well as possible value indexes.
-func fixCase(s string) string {
-	regex := regexp.MustCompile(`\([^()]*\)|[^()\s]+`)
-	slice := regex.FindAllString(s, -1)
-
-	re := regexp.MustCompile(`\((cap|up|low)`)
 
-	for i := 0; i < len(slice); i++ {
-		if re.MatchString(strings.ToLower(slice[i])) {
-			keyWord := re.FindString(strings.ToLower(slice[i]))
-			num := getInt(slice[i])
-			if i > 0 {
-				for j := num; j > 0; j-- {
-					wordToMod := slice[i-j]
-					switch keyWord {
-					case "(cap":
-						wordToMod = strings.ToUpper(wordToMod[:1]) + wordToMod[1:]
-					case "(up":
-						wordToMod = strings.ToUpper(wordToMod)
-					case "(low":
-						wordToMod = strings.ToLower(wordToMod)
-					}
-					slice[i-j] = wordToMod
-				}
-			}
-			slice = append(slice[:i], slice[i+1:]...)
-			i--
-		}
+	temp, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".*.tmp")
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't create a temporary file for %s", name)
 	}
-	return strings.Join(slice, " ")
+	// the temporary file is created with mode 0600, the output keeps the mode it had
+	if err := temp.Chmod(mode); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return nil, nil, fmt.Errorf("couldn't set the mode of the temporary file for %s", name)
+	}
+	return temp, func(ok bool) error {
+		if !ok {
+			return os.Remove(temp.Name())
+		}
+		return os.Rename(temp.Name(), target)
+	}, nil
 }
 
-// Checking if index is a punctuation or if an index includes a punctuation and applies punctuation rules where all punctuation is attached to the previous word and are followed by a space.
-func fixPunctuation(s string) string {
-	slice := strings.Split(s, " ")
+// the standard output is left open after the text is written
+type nopWriteCloser struct{ io.Writer }
 
-	var isPunctuationMark = map[string]bool{
-		".": true,
-		",": true,
-		"!": true,
-		"?": true,
-		":": true,
-		";": true,
-	}
+func (nopWriteCloser) Close() error { return nil }
 
-	for i := 0; i < len(slice); i++ {
-		if i > 0 && isPunctuationMark[slice[i]] {
-			slice[i-1] = slice[i-1] + slice[i]
-			slice = append(slice[:i], slice[i+1:]...)
-			i--
-			continue
-		} else {
-			newWord := ""
-			for j, char := range slice[i] {
-				if j == 0 {
-					newWord = string(char)
-				} else {
-					if !isPunctuationMark[string(char)] && isPunctuationMark[string(newWord[j-1])] {
-						newWord += " "
-					}
-					newWord += string(char)
-				}
-			}
-			if isPunctuationMark[string(newWord[0])] && i > 0 {
-				slice[i-1] = slice[i-1] + newWord
-				slice = append(slice[:i], slice[i+1:]...)
-				i--
-			} else {
-				slice[i] = newWord
-			}
-		}
-	}
+// validating the input (amount of arguments and making sure the input file exists). Any file type is accepted, "-" stands for the standard input or output
+func isValid(arg []string) {
 
-	return strings.Join(slice, " ")
+	if len(arg) != 3 {
+		fmt.Fprintln(os.Stderr, "Error: Invalid amount of arguments.")
+		os.Exit(1) // General error
+	}
+	if arg[1] == "-" {
+		return
+	}
+	info, err := os.Stat(arg[1])
+	if os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: File %s not found.\n", arg[1])
+		os.Exit(3) // File-related error
+	}
+	if err == nil && info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Error: %s is a directory.\n", arg[1])
+		os.Exit(3) // File-related error
+	}
 }
 
-// With the help of boolean value, checking if "'" is the opening or closing quote and builds the new string according to quotation rules. Adding a new line and returning the finalized text to be saved to output.
-// Rules: opening quote preceded by a space and attached to the following word; closing quote followed by a space and attached to the previous word
-func fixQuotation(s string) string {
-	slice := strings.Split(s, " ")
-	newString := ""
+func main() {
 
-	inQuote := false
+	rules := flag.String("rules", strings.Join(reloaded.DefaultOrder, ","), "comma separated list of the rules to apply, in the order they are applied")
+	disable := flag.String("disable", "", "comma separated list of the rules to leave out")
+	list := flag.Bool("list", false, "list the available rules and exit")
+	strict := flag.Bool("strict", false, "fail without writing the output when a marker is invalid, by default invalid markers are left in the text")
+	flag.Parse()
 
-	for _, word := range slice {
-		if len(newString) == 0 && word != "'" {
-			newString = word
-		} else if word == "'" {
-			if inQuote {
-				newString = strings.TrimSpace(newString) + word
-			} else {
-				newString += " " + word
-			}
-			inQuote = !inQuote
-		} else if inQuote && newString[len(newString)-1] == '\'' {
-			newString += word
-		} else {
-			newString += " " + word
+	if *list {
+		for _, name := range reloaded.Default.Names() {
+			fmt.Println(name)
 		}
+		return
 	}
-	return newString
-}
 
-// opens the file location, reads the contents of the file and casts into a string
-func getOriginalText(text string) string {
+	isValid(append([]string{os.Args[0]}, flag.Args()...))
+
+	inputFile := flag.Arg(0)
+	outputFile := flag.Arg(1)
 
-	fileLocation, err := os.Open(text)
+	pipeline, err := composePipeline(*rules, *disable)
 	if err != nil {
-		fmt.Printf("Error: Couldn't open %s file location.\n", text)
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(4) // Invalid input or arguments
 	}
-	defer fileLocation.Close()
 
-	fileContent, err := io.ReadAll(fileLocation)
-	if err != nil {
-		fmt.Println("Error: Couldn't read the file contents.")
+	diagnostics, err := processFile(pipeline, inputFile, outputFile, *strict)
+	printReport(diagnostics)
+	if errors.Is(err, errInvalidMarkers) {
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(4) // Invalid input or arguments
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(3) // File-related error
 	}
-	return (string(fileContent))
 }
 
-// Checking if the output file exists and either create or open the file. Writes the modified content to the output file.
-func writeToFile(file string, content string) {
+// returned in strict mode when a rule found an invalid marker
+var errInvalidMarkers = errors.New("invalid markers in the text, no output written")
 
-	textToFile, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) // WRONGLY opening the file for writing only (not reading), if not existing yet CREATE (self explanatory), TRUNC wipes the contents of the file
+// streaming the input through the pipeline into the output line by line, so files of any size fit in memory.
+// Returns the issues the rules found, in strict mode an invalid marker makes the whole run fail and the output is left as it was.
+func processFile(pipeline *reloaded.Pipeline, inputFile string, outputFile string, strict bool) ([]reloaded.Diagnostic, error) {
+	input, err := openInput(inputFile)
 	if err != nil {
-		fmt.Printf("Error: Couldn't open nor create %s .\n", file)
+		return nil, err
 	}
-	defer textToFile.Close()
+	defer input.Close()
 
-	_, err = textToFile.Write([]byte(content))
+	output, finish, err := createOutput(outputFile, strict)
 	if err != nil {
-		fmt.Printf("Error: Failed to write content to %s .\n", file)
+		return nil, err
 	}
-}
 
-// validating the input (amount of arguments, both files need to be .txt files and making sure the input file exists)
-func isValid(arg []string) {
+	var diagnostics []reloaded.Diagnostic
+	invalid := false
+	report := func(diagnostic reloaded.Diagnostic) {
+		diagnostics = append(diagnostics, diagnostic)
+		invalid = invalid || diagnostic.Severity == reloaded.Error
+	}
 
-	if len(arg) != 3 {
-		fmt.Println("Error: Invalid amount of arguments.")
-		os.Exit(1) // General error
+	if err := pipeline.Stream(input, output, report); err != nil {
+		output.Close()
+		finish(false)
+		return diagnostics, fmt.Errorf("failed to process %s into %s: %v", inputFile, outputFile, err)
 	}
-	for _, file := range arg[1:] {
-		ext := filepath.Ext(file)
-		if strings.ToLower(ext) != ".txt" {
-			fmt.Printf("Error: Input %s has to be a .txt file.\n", file)
-			os.Exit(4) // Invalid input or arguments
-		}
+	if err := output.Close(); err != nil {
+		finish(false)
+		return diagnostics, fmt.Errorf("failed to write content to %s: %v", outputFile, err)
 	}
-	_, err := os.Stat(arg[1])
-	if os.IsNotExist(err) {
-		fmt.Printf("Error: File %s not found.\n", arg[1])
-		os.Exit(3) // File-related error
+	if strict && invalid {
+		finish(false)
+		return diagnostics, errInvalidMarkers
 	}
+	return diagnostics, finish(true)
 }
 
-func main() {
-
-	isValid(os.Args[0:])
-
-	inputFile := os.Args[1]
-	outputFile := os.Args[2]
-
-	originalContent := getOriginalText(inputFile)
-
-	readyContent := handlingContent(originalContent)
-
-	// modifiedContent := fixHexBin(originalContent)
-	// modifiedContent = fixArticle(modifiedContent)
-	// modifiedContent = fixCase(modifiedContent)
-	// modifiedContent = fixPunctuation(modifiedContent)
-	// readyContent := fixQuotation(modifiedContent)
-
-	writeToFile(outputFile, readyContent)
+// listing every issue found in the text on the standard error, so it doesn't mix with the text written to the standard output
+func printReport(diagnostics []reloaded.Diagnostic) {
+	if len(diagnostics) == 0 {
+		return
+	}
+	errorCount := 0
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintln(os.Stderr, diagnostic)
+		if diagnostic.Severity == reloaded.Error {
+			errorCount++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d issue(s): %d error(s), %d warning(s)\n", len(diagnostics), errorCount, len(diagnostics)-errorCount)
 }
 
-func handlingContent(s string) string {
-	contentLines := strings.Split(s, "\n")
+// building the pipeline from the comma separated rule names of the flags, the disabled rules are taken out of the list
+func composePipeline(rules string, disable string) (*reloaded.Pipeline, error) {
+	disabled := make(map[string]bool)
+	for _, name := range splitNames(disable) {
+		if _, ok := reloaded.Default.Lookup(name); !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		disabled[name] = true
+	}
 
-	for i, line := range contentLines {
-		textToModify := line
-		textToModify = fixHexBin(textToModify)
-		textToModify = fixArticle(textToModify)
-		textToModify = fixCase(textToModify)
-		textToModify = fixPunctuation(textToModify)
-		contentLines[i] = fixQuotation(textToModify)
+	var names []string
+	for _, name := range splitNames(rules) {
+		if !disabled[name] {
+			names = append(names, name)
+		}
 	}
+	return reloaded.Default.Pipeline(names)
+}
 
-	return strings.Join(contentLines, "\n")
+// splitting a comma separated list and leaving out the empty names
+func splitNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goreloaded/reloaded"
+)
+
+// writing the text to a new file in a temporary directory
+func writeFile(t *testing.T, dir, name, text string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// A failed strict run leaves an existing output file as it was and no temporary file behind
+func TestStrictKeepsOutput(t *testing.T) {
+	dir := t.TempDir()
+	input := writeFile(t, dir, "input.txt", "zz (hex)\n")
+	output := writeFile(t, dir, "output.txt", "old text\n")
+
+	pipeline, err := composePipeline("hexbin", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diagnostics, err := processFile(pipeline, input, output, true)
+	if !errors.Is(err, errInvalidMarkers) {
+		t.Fatalf("got error %v, want %v", err, errInvalidMarkers)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Severity != reloaded.Error {
+		t.Fatalf("got diagnostics %v, want one error", diagnostics)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "old text\n" {
+		t.Errorf("output is %q, want it unchanged", content)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("got %d files in the directory, want only the input and the output", len(entries))
+	}
+}
+
+// Writing over the input file itself keeps its mode, the temporary file would otherwise leave it at 0600
+func TestInPlaceKeepsMode(t *testing.T) {
+	dir := t.TempDir()
+	input := writeFile(t, dir, "input.txt", "1E (hex) files\n")
+	if err := os.Chmod(input, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := composePipeline("hexbin", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := processFile(pipeline, input, input, false); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "30 files\n" {
+		t.Errorf("got %q, want %q", content, "30 files\n")
+	}
+	info, err := os.Stat(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0644 {
+		t.Errorf("got mode %v, want %v", mode, os.FileMode(0644))
+	}
+}
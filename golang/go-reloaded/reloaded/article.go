@@ -0,0 +1,17 @@
+package reloaded
+
+import "strings"
+
+// finding the words "a" and "A" and checking if the following word starts with a vowel. If yes, adding "n" to the end of the word.
+func fixArticle(tokens []Token) ([]Token, []Diagnostic) {
+	for i := range tokens {
+		if tokens[i].Kind != Word || strings.ToLower(tokens[i].Text) != "a" {
+			continue
+		}
+		next := nextToken(tokens, i)
+		if next > i+1 && (tokens[next].Kind == Word || tokens[next].Kind == Number) && strings.ContainsRune("aeiouAEIOU", []rune(tokens[next].Text)[0]) {
+			tokens[i].Text += "n"
+		}
+	}
+	return tokens, nil
+}
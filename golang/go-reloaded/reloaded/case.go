@@ -0,0 +1,62 @@
+package reloaded
+
+import (
+	"strconv"
+	"strings"
+)
+
+// finding the case markers (cap) (up) (low), optionally with the number of words like (cap, 2), and converting the previous word(s) accordingly. The marker is removed.
+// A marker with an invalid number of words or without any word in front of it is reported and left unchanged.
+func fixCase(tokens []Token) ([]Token, []Diagnostic) {
+	var diagnostics []Diagnostic
+
+	for i := 0; i < len(tokens); i++ {
+		marker := tokens[i]
+		if marker.Kind != Marker || (marker.Name != "cap" && marker.Name != "up" && marker.Name != "low") {
+			continue
+		}
+
+		count := 1
+		if len(marker.Args) > 1 {
+			diagnostics = append(diagnostics, diagnose(marker, Error, "%s takes at most one argument", marker.Text))
+			continue
+		}
+		if len(marker.Args) == 1 {
+			number, err := strconv.Atoi(marker.Args[0])
+			if err != nil || number < 1 {
+				diagnostics = append(diagnostics, diagnose(marker, Error, "invalid number of words %q in %s", marker.Args[0], marker.Text))
+				continue
+			}
+			count = number
+		}
+
+		// going back over the words, punctuation and whitespace in between are skipped
+		asked := count
+		for j := i - 1; j >= 0 && count > 0; j-- {
+			if tokens[j].Kind != Word && tokens[j].Kind != Number {
+				continue
+			}
+			wordToMod := tokens[j].Text
+			switch marker.Name {
+			case "cap":
+				runes := []rune(wordToMod)
+				wordToMod = strings.ToUpper(string(runes[:1])) + string(runes[1:])
+			case "up":
+				wordToMod = strings.ToUpper(wordToMod)
+			case "low":
+				wordToMod = strings.ToLower(wordToMod)
+			}
+			tokens[j].Text = wordToMod
+			count--
+		}
+		if count == asked {
+			diagnostics = append(diagnostics, diagnose(marker, Error, "no word in front of %s", marker.Text))
+			continue
+		}
+		if count > 0 {
+			diagnostics = append(diagnostics, diagnose(marker, Warning, "%d word(s) fewer than asked in front of %s", count, marker.Text))
+		}
+		tokens, i = removeMarker(tokens, i)
+	}
+	return tokens, diagnostics
+}
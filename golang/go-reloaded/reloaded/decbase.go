@@ -0,0 +1,28 @@
+package reloaded
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// conversions of the (dec-to-hex) and (dec-to-bin) markers, the decimal number before the marker is written in the base
+var fromDecimal = map[string]func(string) (string, error){
+	"dec-to-hex": formatBase(16),
+	"dec-to-bin": formatBase(2),
+}
+
+func formatBase(base int) func(string) (string, error) {
+	return func(value string) (string, error) {
+		decimalValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid decimal value %q", value)
+		}
+		return strings.ToUpper(strconv.FormatInt(decimalValue, base)), nil
+	}
+}
+
+// finding the (dec-to-hex) and (dec-to-bin) markers and converting the number before them from decimal to hexadecimal or binary. The marker is removed.
+func fixDecBase(tokens []Token) ([]Token, []Diagnostic) {
+	return convertValues(tokens, fromDecimal)
+}
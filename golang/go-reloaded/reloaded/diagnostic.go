@@ -0,0 +1,37 @@
+package reloaded
+
+import "fmt"
+
+// Severity tells how bad an issue found by a rule is
+type Severity int
+
+const (
+	Warning Severity = iota // the rule did less than asked, like (cap, 3) after two words, the text is still fine
+	Error                   // the marker is invalid or has no word in front of it, it and the text around it are left unchanged
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Diagnostic is an issue a rule found in the text. Line and Column point to the token, both counted from 1.
+type Diagnostic struct {
+	Line     int
+	Column   int
+	Severity Severity
+	Rule     string
+	Message  string
+}
+
+// formatting the diagnostic like "3:12: error: hexbin: invalid hexadecimal value "zz""
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %v: %s: %s", d.Line, d.Column, d.Severity, d.Rule, d.Message)
+}
+
+// creating a diagnostic for the token, the pipeline fills in the line and the rule
+func diagnose(token Token, severity Severity, format string, args ...any) Diagnostic {
+	return Diagnostic{Column: token.Column, Severity: severity, Message: fmt.Sprintf(format, args...)}
+}
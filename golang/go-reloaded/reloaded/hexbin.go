@@ -0,0 +1,67 @@
+package reloaded
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// conversions of the (hex) (bin) (oct) markers, the word before the marker is read in the base and written in decimal
+var toDecimal = map[string]func(string) (string, error){
+	"hex": parseBase(16, "hexadecimal"),
+	"bin": parseBase(2, "binary"),
+	"oct": parseBase(8, "octal"),
+}
+
+func parseBase(base int, baseName string) func(string) (string, error) {
+	return func(value string) (string, error) {
+		decimalValue, err := strconv.ParseInt(value, base, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s value %q", baseName, value)
+		}
+		return strconv.FormatInt(decimalValue, 10), nil
+	}
+}
+
+// finding the (hex) (bin) and (oct) markers and converting the word before them from hexadecimal, binary or octal to decimal. The marker is removed.
+func fixHexBin(tokens []Token) ([]Token, []Diagnostic) {
+	return convertValues(tokens, toDecimal)
+}
+
+// Replacing the word in front of every marker named in conversions by its conversion, the marker is removed.
+// A value the conversion rejects is reported and left unchanged together with its marker.
+func convertValues(tokens []Token, conversions map[string]func(string) (string, error)) ([]Token, []Diagnostic) {
+	var diagnostics []Diagnostic
+
+	for i := 0; i < len(tokens); i++ {
+		marker := tokens[i]
+		convert, ok := conversions[marker.Name]
+		if marker.Kind != Marker || !ok {
+			continue
+		}
+		if len(marker.Args) > 0 {
+			diagnostics = append(diagnostics, diagnose(marker, Error, "%s takes no arguments", marker.Text))
+			continue
+		}
+		prev := previousToken(tokens, i)
+		if prev < 0 || (tokens[prev].Kind != Word && tokens[prev].Kind != Number) {
+			diagnostics = append(diagnostics, diagnose(marker, Error, "no value in front of %s", marker.Text))
+			continue
+		}
+
+		converted, err := convert(tokens[prev].Text)
+		if err != nil {
+			diagnostics = append(diagnostics, diagnose(tokens[prev], Error, "%v in front of %s", err, marker.Text))
+			continue
+		}
+
+		// a conversion of several words, like "one hundred fifteen", becomes a token for every word so later rules see them all
+		replacement := Lex(converted)
+		for j := range replacement {
+			replacement[j].Column = tokens[prev].Column
+		}
+		tokens = append(tokens[:prev], append(replacement, tokens[prev+1:]...)...)
+		i += len(replacement) - 1
+		tokens, i = removeMarker(tokens, i)
+	}
+	return tokens, diagnostics
+}
@@ -0,0 +1,58 @@
+package reloaded
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Running the number rules on single lines and checking the text and the diagnostics
+func TestNumberMarkers(t *testing.T) {
+	pipeline, err := Default.Pipeline([]string{"hexbin", "decbase", "roman", "words", "article", "case"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		line        string
+		want        string
+		diagnostics []string
+	}{
+		{"hex", "1E (hex) files", "30 files", nil},
+		{"bin", "It has 10 (bin) sides", "It has 2 sides", nil},
+		{"oct", "17 (oct) and 777 (OCT)", "15 and 511", nil},
+		{"dec-to-hex", "255 (dec-to-hex) is 0 (dec-to-hex)", "FF is 0", nil},
+		{"dec-to-bin", "10 (dec-to-bin) and -3 (dec-to-bin)", "1010 and -11", nil},
+		{"roman", "in 2007 (roman) and 14 (to-roman)", "in MMVII and XIV", nil},
+		{"words", "a 8 (words) bit value", "an eight bit value", nil},
+		{"words then case", "115 (words) (cap, 3) people", "One Hundred Fifteen people", nil},
+		{"chained", "17 (oct) (words)", "fifteen", nil},
+
+		// Invalid markers are left in the text with their values
+		{"invalid hex", "zz (hex) ok", "zz (hex) ok", []string{`1:1: error: hexbin: invalid hexadecimal value "zz" in front of (hex)`}},
+		{"invalid bin", "12 (bin)", "12 (bin)", []string{`1:1: error: hexbin: invalid binary value "12" in front of (bin)`}},
+		{"invalid oct", "89 (oct)", "89 (oct)", []string{`1:1: error: hexbin: invalid octal value "89" in front of (oct)`}},
+		{"invalid decimal", "x (dec-to-hex)", "x (dec-to-hex)", []string{`1:1: error: decbase: invalid decimal value "x" in front of (dec-to-hex)`}},
+		{"roman out of range", "4000 (roman)", "4000 (roman)", []string{`1:1: error: roman: 4000 is outside of the roman numerals 1 to 3999 in front of (roman)`}},
+		{"words with an argument", "8 (words, 2)", "8 (words, 2)", []string{`1:3: error: words: (words, 2) takes no arguments`}},
+		{"nothing in front", "(words) here", "(words) here", []string{`1:1: error: words: no value in front of (words)`}},
+		{"no word in front", "(up) here", "(up) here", []string{`1:1: error: case: no word in front of (up)`}},
+		{"fewer words than asked", "two words (cap, 3)", "Two Words", []string{`1:11: warning: case: 1 word(s) fewer than asked in front of (cap, 3)`}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, diagnostics := pipeline.ApplyLine(test.line, 1)
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			var messages []string
+			for _, diagnostic := range diagnostics {
+				messages = append(messages, diagnostic.String())
+			}
+			if !reflect.DeepEqual(messages, test.diagnostics) {
+				t.Errorf("got diagnostics %q, want %q", messages, test.diagnostics)
+			}
+		})
+	}
+}
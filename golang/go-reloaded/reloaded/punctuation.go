@@ -0,0 +1,20 @@
+package reloaded
+
+// attaching every punctuation mark to the previous word and putting a space between the last mark and the next word, so "hard ! ! this" becomes "hard!! this"
+func fixPunctuation(tokens []Token) ([]Token, []Diagnostic) {
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != Punctuation {
+			continue
+		}
+		// the whitespace before the mark goes, unless the mark starts the line
+		if i > 0 && tokens[i-1].Kind == Space && previousToken(tokens, i) >= 0 {
+			tokens = append(tokens[:i-1], tokens[i:]...)
+			i--
+		}
+		// a word right after the mark gets a space in between
+		if next := i + 1; next < len(tokens) && tokens[next].Kind != Space && tokens[next].Kind != Punctuation {
+			tokens = append(tokens[:next], append([]Token{{Kind: Space, Text: " "}}, tokens[next:]...)...)
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,36 @@
+package reloaded
+
+// pairing the ' quotes as opening and closing quotes and attaching them to the quoted words.
+// Rules: opening quote preceded by a space and attached to the following word; closing quote attached to the previous word
+func fixQuotation(tokens []Token) ([]Token, []Diagnostic) {
+	inQuote := false
+	var opening Token
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != Quote {
+			continue
+		}
+		if inQuote {
+			// closing quote: the whitespace before it goes
+			for i > 0 && tokens[i-1].Kind == Space {
+				tokens = append(tokens[:i-1], tokens[i:]...)
+				i--
+			}
+		} else {
+			opening = tokens[i]
+			// opening quote: the whitespace after it goes and a space is put before it when missing
+			for i+1 < len(tokens) && tokens[i+1].Kind == Space {
+				tokens = append(tokens[:i+1], tokens[i+2:]...)
+			}
+			if i > 0 && tokens[i-1].Kind != Space {
+				tokens = append(tokens[:i], append([]Token{{Kind: Space, Text: " "}}, tokens[i:]...)...)
+				i++
+			}
+		}
+		inQuote = !inQuote
+	}
+	if inQuote {
+		return tokens, []Diagnostic{diagnose(opening, Warning, "quote is never closed")}
+	}
+	return tokens, nil
+}
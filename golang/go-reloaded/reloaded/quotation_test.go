@@ -0,0 +1,41 @@
+package reloaded
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuotation(t *testing.T) {
+	pipeline, err := Default.Pipeline([]string{"punctuation", "quotation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line        string
+		want        string
+		diagnostics []string
+	}{
+		{"I am ' awesome '", "I am 'awesome'", nil},
+		{"the students' books are here", "the students' books are here", nil},
+		{"the dogs' bones .", "the dogs' bones.", nil},
+		{"he said ' the dogs ' and left", "he said 'the dogs' and left", nil},
+		{"'tis the season", "'tis the season", nil},
+		{"' tis the season", "'tis the season", []string{"1:1: warning: quotation: quote is never closed"}},
+		{"don't ' stop '", "don't 'stop'", nil},
+	}
+
+	for _, test := range tests {
+		got, diagnostics := pipeline.ApplyLine(test.line, 1)
+		if got != test.want {
+			t.Errorf("ApplyLine(%q) = %q, want %q", test.line, got, test.want)
+		}
+		var messages []string
+		for _, diagnostic := range diagnostics {
+			messages = append(messages, diagnostic.String())
+		}
+		if !reflect.DeepEqual(messages, test.diagnostics) {
+			t.Errorf("ApplyLine(%q) gave diagnostics %q, want %q", test.line, messages, test.diagnostics)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package reloaded
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// values of the roman numerals from the largest down, including the subtractive pairs like IV
+var romanNumerals = []struct {
+	value   int
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// the (roman) and (to-roman) markers both write the number before them in roman numerals
+var toRoman = map[string]func(string) (string, error){
+	"roman":    formatRoman,
+	"to-roman": formatRoman,
+}
+
+// writing a decimal number from 1 to 3999 in roman numerals, e.g. 2024 becomes MMXXIV
+func formatRoman(value string) (string, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid decimal value %q", value)
+	}
+	if number < 1 || number > 3999 {
+		return "", fmt.Errorf("%d is outside of the roman numerals 1 to 3999", number)
+	}
+
+	var numeral strings.Builder
+	for _, roman := range romanNumerals {
+		for number >= roman.value {
+			numeral.WriteString(roman.numeral)
+			number -= roman.value
+		}
+	}
+	return numeral.String(), nil
+}
+
+// finding the (roman) and (to-roman) markers and converting the number before them to roman numerals. The marker is removed.
+func fixRoman(tokens []Token) ([]Token, []Diagnostic) {
+	return convertValues(tokens, toRoman)
+}
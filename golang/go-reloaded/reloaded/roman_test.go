@@ -0,0 +1,33 @@
+package reloaded
+
+import "testing"
+
+func TestFormatRoman(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr string
+	}{
+		{"0", "", "0 is outside of the roman numerals 1 to 3999"},
+		{"1", "I", ""},
+		{"14", "XIV", ""},
+		{"2024", "MMXXIV", ""},
+		{"3999", "MMMCMXCIX", ""},
+		{"4000", "", "4000 is outside of the roman numerals 1 to 3999"},
+		{"-5", "", "-5 is outside of the roman numerals 1 to 3999"},
+		{"XIV", "", `invalid decimal value "XIV"`},
+	}
+
+	for _, test := range tests {
+		got, err := formatRoman(test.value)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("formatRoman(%q) error = %v, want %q", test.value, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("formatRoman(%q) = %q, %v, want %q", test.value, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package reloaded
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Rule is one transformation of the text, it gets the tokens of a line and returns the modified tokens and the issues it found.
+// Tokens a rule does not touch, whitespace included, should be returned as they are. A rule never stops the program,
+// an invalid marker is reported as an Error diagnostic and left in the text together with the words it would change.
+type Rule interface {
+	Name() string
+	Apply(tokens []Token) ([]Token, []Diagnostic)
+}
+
+// rule made from a name and a function, used for the built-in rules and by NewRule
+type funcRule struct {
+	name  string
+	apply func([]Token) ([]Token, []Diagnostic)
+}
+
+func (r funcRule) Name() string                                 { return r.name }
+func (r funcRule) Apply(tokens []Token) ([]Token, []Diagnostic) { return r.apply(tokens) }
+
+// NewRule turns a function working on the tokens of a line into a Rule with the given name
+func NewRule(name string, apply func(tokens []Token) ([]Token, []Diagnostic)) Rule {
+	return funcRule{name: name, apply: apply}
+}
+
+// NewTextRule turns a function working on the text of a line into a Rule, the result is split into tokens again
+func NewTextRule(name string, apply func(line string) string) Rule {
+	return NewRule(name, func(tokens []Token) ([]Token, []Diagnostic) {
+		return Lex(apply(Render(tokens))), nil
+	})
+}
+
+// Registry keeps the rules by their names so that they can be picked and ordered by name
+type Registry struct {
+	rules map[string]Rule
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]Rule)}
+}
+
+// Register adds the rule to the registry, the name has to be unique
+func (r *Registry) Register(rule Rule) error {
+	name := rule.Name()
+	if name == "" || strings.ContainsAny(name, ", ") {
+		return fmt.Errorf("invalid rule name %q", name)
+	}
+	if _, exists := r.rules[name]; exists {
+		return fmt.Errorf("rule %q is already registered", name)
+	}
+	r.rules[name] = rule
+	return nil
+}
+
+// Lookup finds a registered rule by its name
+func (r *Registry) Lookup(name string) (Rule, bool) {
+	rule, ok := r.rules[name]
+	return rule, ok
+}
+
+// Names lists the names of the registered rules in alphabetical order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.rules))
+	for name := range r.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Pipeline builds a pipeline of the named rules in the given order, unknown names are reported as an error
+func (r *Registry) Pipeline(names []string) (*Pipeline, error) {
+	var rules []Rule
+	for _, name := range names {
+		rule, ok := r.rules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		rules = append(rules, rule)
+	}
+	return NewPipeline(rules...), nil
+}
+
+// DefaultOrder is the order the built-in rules are applied in when nothing else is asked
+var DefaultOrder = []string{"hexbin", "decbase", "roman", "words", "article", "case", "punctuation", "quotation"}
+
+// Default is the registry with the built-in rules, third-party rules can be added to it with Register
+var Default = NewRegistry()
+
+func init() {
+	Default.Register(NewRule("hexbin", fixHexBin))
+	Default.Register(NewRule("decbase", fixDecBase))
+	Default.Register(NewRule("roman", fixRoman))
+	Default.Register(NewRule("words", fixWords))
+	Default.Register(NewRule("article", fixArticle))
+	Default.Register(NewRule("case", fixCase))
+	Default.Register(NewRule("punctuation", fixPunctuation))
+	Default.Register(NewRule("quotation", fixQuotation))
+}
+
+// Register adds a rule to the default registry
+func Register(rule Rule) error {
+	return Default.Register(rule)
+}
+
+// Pipeline applies its rules one after another to every line of the text
+type Pipeline struct {
+	rules []Rule
+}
+
+// NewPipeline creates a pipeline of the given rules in the given order
+func NewPipeline(rules ...Rule) *Pipeline {
+	return &Pipeline{rules: rules}
+}
+
+// Rules returns the rules of the pipeline in the order they are applied
+func (p *Pipeline) Rules() []Rule {
+	return p.rules
+}
+
+// ApplyLine splits the line into tokens once, runs them through every rule of the pipeline and puts the line back together.
+// The diagnostics of the rules get the given line number.
+func (p *Pipeline) ApplyLine(line string, number int) (string, []Diagnostic) {
+	var diagnostics []Diagnostic
+	tokens := Lex(line)
+	for _, rule := range p.rules {
+		var found []Diagnostic
+		tokens, found = rule.Apply(tokens)
+		for _, diagnostic := range found {
+			diagnostic.Line, diagnostic.Rule = number, rule.Name()
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return Render(tokens), diagnostics
+}
+
+// Apply splits the text into lines and runs every line through the pipeline
+func (p *Pipeline) Apply(text string) (string, []Diagnostic) {
+	var diagnostics []Diagnostic
+	contentLines := strings.Split(text, "\n")
+
+	for i, line := range contentLines {
+		var found []Diagnostic
+		contentLines[i], found = p.ApplyLine(line, i+1)
+		diagnostics = append(diagnostics, found...)
+	}
+
+	return strings.Join(contentLines, "\n"), diagnostics
+}
+
+// Stream reads the text line by line, runs every line through the pipeline and writes it out right away,
+// so only one line is kept in memory. The line endings ("\n" or "\r\n") are written back as they were read.
+// The diagnostics are handed to report as they are found, report may be nil.
+func (p *Pipeline) Stream(r io.Reader, w io.Writer, report func(Diagnostic)) error {
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+
+	for number := 1; ; number++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if line == "" && err == io.EOF {
+			break
+		}
+
+		content := strings.TrimRight(line, "\r\n")
+		readyContent, diagnostics := p.ApplyLine(content, number)
+		if report != nil {
+			for _, diagnostic := range diagnostics {
+				report(diagnostic)
+			}
+		}
+		if _, writeErr := writer.WriteString(readyContent + line[len(content):]); writeErr != nil {
+			return writeErr
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return writer.Flush()
+}
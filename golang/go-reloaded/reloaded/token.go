@@ -0,0 +1,179 @@
+package reloaded
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// TokenKind tells what a piece of a line is
+type TokenKind int
+
+const (
+	Word        TokenKind = iota // any run of letters, digits and other characters that are not listed below
+	Number                       // a word made of digits only
+	Punctuation                  // one of . , ! ? : ;
+	Quote                        // a ' that is not an apostrophe, possessive or elision belonging to a word
+	Marker                       // a command like (up) or (cap, 2)
+	Space                        // a run of spaces and tabs
+)
+
+// Token is one piece of a line. Text is the token as it is written, putting the Text of all tokens together gives back the line.
+// For markers Name is the lowercase command and Args holds the values after the commas, e.g. (Cap, 2) has Name "cap" and Args ["2"].
+// Column is the position of the first character in the line counted from 1, tokens added by the rules have 0.
+type Token struct {
+	Kind   TokenKind
+	Text   string
+	Name   string
+	Args   []string
+	Column int
+}
+
+// matching a marker: a name in parentheses, optionally followed by comma separated values
+var markerPattern = regexp.MustCompile(`^\(\s*([A-Za-z][A-Za-z-]*)\s*((?:,\s*[^,()\s]+\s*)*)\)`)
+
+// checking if the character is one of the punctuation marks the punctuation rule takes care of
+func isPunctuationMark(char rune) bool {
+	return strings.ContainsRune(".,!?:;", char)
+}
+
+// Lex splits the line into tokens, every character of the line belongs to exactly one token
+func Lex(line string) []Token {
+	var tokens []Token
+	runes := []rune(line)
+	inQuote := false
+
+	// a ' belongs to the word when it is an apostrophe like in don't, an elision like in 'tis,
+	// or a possessive like in students' while no quote is open
+	partOfWord := func(i int) bool {
+		return isApostrophe(runes, i) || isElision(runes, i) || !inQuote && isPossessive(runes, i)
+	}
+
+	for i := 0; i < len(runes); {
+		char := runes[i]
+		start := i
+
+		switch {
+		case char == ' ' || char == '\t':
+			for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t') {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: Space, Text: string(runes[start:i])})
+
+		case char == '(' && markerPattern.MatchString(string(runes[i:])):
+			match := markerPattern.FindStringSubmatch(string(runes[i:]))
+			token := Token{Kind: Marker, Text: match[0], Name: strings.ToLower(match[1])}
+			for _, arg := range strings.Split(match[2], ",")[1:] {
+				token.Args = append(token.Args, strings.TrimSpace(arg))
+			}
+			tokens = append(tokens, token)
+			i += len([]rune(match[0]))
+
+		case isPunctuationMark(char):
+			tokens = append(tokens, Token{Kind: Punctuation, Text: string(char)})
+			i++
+
+		case char == '\'' && !partOfWord(i):
+			tokens = append(tokens, Token{Kind: Quote, Text: "'"})
+			inQuote = !inQuote
+			i++
+
+		default:
+			// a word goes on until a space, a punctuation mark, a quote or a marker
+			for i < len(runes) {
+				c := runes[i]
+				if c == ' ' || c == '\t' || isPunctuationMark(c) || (c == '\'' && !partOfWord(i)) ||
+					(i > start && c == '(' && markerPattern.MatchString(string(runes[i:]))) {
+					break
+				}
+				i++
+			}
+			kind := Word
+			if isDigits(runes[start:i]) {
+				kind = Number
+			}
+			tokens = append(tokens, Token{Kind: kind, Text: string(runes[start:i])})
+		}
+		tokens[len(tokens)-1].Column = start + 1
+	}
+	return tokens
+}
+
+// an apostrophe is a ' between two letters, like in don't
+func isApostrophe(runes []rune, i int) bool {
+	return i > 0 && i < len(runes)-1 && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1])
+}
+
+// a possessive is a ' after a letter at the end of a word, like in the students' books
+func isPossessive(runes []rune, i int) bool {
+	return i > 0 && unicode.IsLetter(runes[i-1]) &&
+		(i == len(runes)-1 || runes[i+1] == ' ' || runes[i+1] == '\t' || isPunctuationMark(runes[i+1]))
+}
+
+// words that start with a ' standing for left out letters
+var elisions = []string{"tis", "twas"}
+
+// an elision is a ' at the start of a word like 'tis, it is not an opening quote
+func isElision(runes []rune, i int) bool {
+	if i > 0 && runes[i-1] != ' ' && runes[i-1] != '\t' {
+		return false
+	}
+	for _, elision := range elisions {
+		end := i + 1 + len(elision)
+		if end <= len(runes) && strings.EqualFold(string(runes[i+1:end]), elision) &&
+			(end == len(runes) || !unicode.IsLetter(runes[end])) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(runes []rune) bool {
+	for _, char := range runes {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return len(runes) > 0
+}
+
+// Render puts the tokens back together into a line
+func Render(tokens []Token) string {
+	var line strings.Builder
+	for _, token := range tokens {
+		line.WriteString(token.Text)
+	}
+	return line.String()
+}
+
+// finding the closest token before index i that is not whitespace, -1 when there is none
+func previousToken(tokens []Token, i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if tokens[j].Kind != Space {
+			return j
+		}
+	}
+	return -1
+}
+
+// finding the closest token after index i that is not whitespace, -1 when there is none
+func nextToken(tokens []Token, i int) int {
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].Kind != Space {
+			return j
+		}
+	}
+	return -1
+}
+
+// removing the marker at index i together with the whitespace in front of it, so "word (up) next" becomes "word next".
+// Returns the tokens and the index to continue from.
+func removeMarker(tokens []Token, i int) ([]Token, int) {
+	from, to := i, i+1
+	if i > 0 && tokens[i-1].Kind == Space {
+		from--
+	} else if i == 0 && to < len(tokens) && tokens[to].Kind == Space {
+		to++ // at the start of the line the whitespace after the marker goes instead
+	}
+	return append(tokens[:from], tokens[to:]...), from - 1
+}
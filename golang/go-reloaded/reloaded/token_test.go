@@ -0,0 +1,33 @@
+package reloaded
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Checking which ' the lexer keeps in a word and which become quotes
+func TestLexQuotes(t *testing.T) {
+	tests := []struct {
+		line  string
+		kinds []TokenKind
+	}{
+		{"don't", []TokenKind{Word}},
+		{"'yes'", []TokenKind{Quote, Word, Quote}},
+		{"the students' books", []TokenKind{Word, Space, Word, Space, Word}},
+		{"the dogs'.", []TokenKind{Word, Space, Word, Punctuation}},
+		{"'tis the season", []TokenKind{Word, Space, Word, Space, Word}},
+		{"'Twas late", []TokenKind{Word, Space, Word}},
+		{"'tiss'", []TokenKind{Quote, Word, Quote}},                                     // Not an elision, only 'tis is
+		{"'the dogs' bones", []TokenKind{Quote, Word, Space, Word, Quote, Space, Word}}, // Inside a quote a trailing ' closes it
+	}
+
+	for _, test := range tests {
+		var kinds []TokenKind
+		for _, token := range Lex(test.line) {
+			kinds = append(kinds, token.Kind)
+		}
+		if !reflect.DeepEqual(kinds, test.kinds) {
+			t.Errorf("Lex(%q) gave kinds %v, want %v", test.line, kinds, test.kinds)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package reloaded
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	smallNumbers = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	// names of the groups of three digits, from the lowest up
+	scales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// the (words) marker spells out the number before it
+var toWords = map[string]func(string) (string, error){
+	"words": spellNumber,
+}
+
+// spelling out a decimal number in English, e.g. 1042 becomes "one thousand forty-two" and -7 becomes "minus seven"
+func spellNumber(value string) (string, error) {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid decimal value %q", value)
+	}
+	if number == 0 {
+		return smallNumbers[0], nil
+	}
+
+	// working on the unsigned value so the smallest int64 can be negated
+	var words []string
+	magnitude := uint64(number)
+	if number < 0 {
+		words = append(words, "minus")
+		magnitude = -magnitude
+	}
+
+	// spelling the groups of three digits from the highest down, empty groups are left out
+	var groups []uint64
+	for ; magnitude > 0; magnitude /= 1000 {
+		groups = append(groups, magnitude%1000)
+	}
+	for scale := len(groups) - 1; scale >= 0; scale-- {
+		if groups[scale] == 0 {
+			continue
+		}
+		words = append(words, spellHundreds(int(groups[scale])))
+		if scales[scale] != "" {
+			words = append(words, scales[scale])
+		}
+	}
+	return strings.Join(words, " "), nil
+}
+
+// spelling out a number from 1 to 999, e.g. 342 becomes "three hundred forty-two"
+func spellHundreds(number int) string {
+	var words []string
+	if number >= 100 {
+		words = append(words, smallNumbers[number/100], "hundred")
+		number %= 100
+	}
+	switch {
+	case number >= 20 && number%10 != 0:
+		words = append(words, tens[number/10]+"-"+smallNumbers[number%10])
+	case number >= 20:
+		words = append(words, tens[number/10])
+	case number > 0:
+		words = append(words, smallNumbers[number])
+	}
+	return strings.Join(words, " ")
+}
+
+// finding the (words) markers and spelling out the number before them in English. The marker is removed.
+// Every spelled out word counts as a word of its own for the later rules, e.g. 115 (words) (cap, 3) becomes One Hundred Fifteen.
+func fixWords(tokens []Token) ([]Token, []Diagnostic) {
+	return convertValues(tokens, toWords)
+}
@@ -0,0 +1,36 @@
+package reloaded
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestSpellNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"0", "zero"},
+		{"7", "seven"},
+		{"21", "twenty-one"},
+		{"40", "forty"},
+		{"115", "one hundred fifteen"},
+		{"1042", "one thousand forty-two"},
+		{"1000000", "one million"},
+		{"-7", "minus seven"},
+		{strconv.FormatInt(math.MaxInt64, 10), "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven"},
+		{strconv.FormatInt(math.MinInt64, 10), "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+	}
+
+	for _, test := range tests {
+		got, err := spellNumber(test.value)
+		if err != nil || got != test.want {
+			t.Errorf("spellNumber(%q) = %q, %v, want %q", test.value, got, err, test.want)
+		}
+	}
+
+	if _, err := spellNumber("twelve"); err == nil || err.Error() != `invalid decimal value "twelve"` {
+		t.Errorf("spellNumber(\"twelve\") error = %v", err)
+	}
+}
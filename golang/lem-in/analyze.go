@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"lemin/lemin"
+)
+
+// Longest room list printed in the analysis, longer lists are cut
+const maxListed = 20
+
+// Running the analyze subcommand: printing the graph properties of a colony without solving it
+func runAnalyze(args []string) {
+	flags := flag.NewFlagSet("analyze", flag.ExitOnError)
+	multi := flags.Bool("multi", false, "allow several ##start and ##end rooms")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		Exit("Usage: 'go run . analyze [-multi] [colony]' (use '-' to read from standard input)")
+	}
+
+	source, err := openColony(flags.Arg(0))
+	if err != nil {
+		Exit(fmt.Sprint("Error reading the colony: ", err))
+	}
+	defer source.Close()
+	colony, err := lemin.ParseWithOptions(source.Reader(), lemin.ParseOptions{Multi: *multi})
+	if err != nil {
+		Exit(fmt.Sprint("ERROR: invalid data format: ", err))
+	}
+
+	PrintAnalysis(os.Stdout, colony, lemin.Analyze(colony))
+}
+
+// Print the graph properties of the colony
+func PrintAnalysis(w io.Writer, colony *lemin.Colony, analysis *lemin.Analysis) {
+	fmt.Fprintf(w, "Ants: %d\n", colony.NumAnts)
+	fmt.Fprintf(w, "Rooms: %d, tunnels: %d\n", analysis.Rooms, analysis.Tunnels)
+
+	// The groups without a start or end room are listed, the main ones only named
+	fmt.Fprintf(w, "Connected components: %d\n", len(analysis.Components))
+	for i, group := range analysis.Components {
+		var starts, ends []string
+		for _, room := range group {
+			if colony.IsStart(room) {
+				starts = append(starts, room)
+			} else if colony.IsEnd(room) {
+				ends = append(ends, room)
+			}
+		}
+		if len(starts) > 0 || len(ends) > 0 {
+			var roles []string
+			if len(starts) > 0 {
+				roles = append(roles, "start: "+strings.Join(starts, ", "))
+			}
+			if len(ends) > 0 {
+				roles = append(roles, "end: "+strings.Join(ends, ", "))
+			}
+			fmt.Fprintf(w, "  Component %d: %s (%s)\n", i+1, countRooms(len(group)), strings.Join(roles, ", "))
+		} else {
+			fmt.Fprintf(w, "  Component %d: %s\n", i+1, listRooms(group))
+		}
+	}
+
+	fmt.Fprintf(w, "Unreachable from start: %s\n", listRooms(analysis.Unreachable))
+	fmt.Fprintf(w, "Dead ends: %s\n", listRooms(analysis.DeadEnds))
+
+	if analysis.ShortestPath == nil {
+		fmt.Fprintln(w, "Bottlenecks: -")
+		fmt.Fprintln(w, "Disjoint paths: 0")
+		fmt.Fprintln(w, "Shortest path: none, no end room can be reached from a start room")
+		return
+	}
+	fmt.Fprintf(w, "Bottlenecks: %s\n", listRooms(analysis.Bottlenecks))
+	fmt.Fprintf(w, "Disjoint paths: %d\n", analysis.DisjointPaths)
+	fmt.Fprintf(w, "Shortest path: length %d: %s\n", colony.PathLength(analysis.ShortestPath), strings.Join(analysis.ShortestPath, "-"))
+}
+
+// Room names with their count, cut after maxListed names
+func listRooms(rooms []string) string {
+	if len(rooms) == 0 {
+		return "none"
+	}
+	if len(rooms) > maxListed {
+		return fmt.Sprintf("%s: %s ... (%d more)", countRooms(len(rooms)), strings.Join(rooms[:maxListed], ", "), len(rooms)-maxListed)
+	}
+	return fmt.Sprintf("%s: %s", countRooms(len(rooms)), strings.Join(rooms, ", "))
+}
+
+func countRooms(count int) string {
+	if count == 1 {
+		return "1 room"
+	}
+	return fmt.Sprintf("%d rooms", count)
+}
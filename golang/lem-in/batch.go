@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"lemin/lemin"
+)
+
+// Result of solving one colony file in batch mode
+type batchResult struct {
+	ants     int
+	rooms    int
+	turns    int
+	moves    []string
+	expected string // Turn count or "error" from expected.list or the "# expected: N" comment, empty when not given
+	elapsed  time.Duration
+	err      error // The colony was rejected
+	broken   error // The solution breaks a rule or takes too many turns
+}
+
+// Running the batch subcommand: solving every colony of a directory and comparing the turns to the expected ones
+func runBatch(args []string) {
+	flags := flag.NewFlagSet("batch", flag.ExitOnError)
+	golden := flags.Bool("golden", false, "compare the moves of every colony to its .golden file")
+	update := flags.Bool("update", false, "write the .golden file of every colony from the current moves")
+	random := flags.Int("random", 0, "also solve the given number of generated colonies (seeds 1 to N) and check their solutions")
+	flags.Parse(args)
+	if flags.NArg() > 1 || flags.NArg() == 0 && *random <= 0 {
+		Exit("Usage: 'go run . batch [-golden] [-update] [-random N] [directory]'")
+	}
+
+	var files []string
+	expected := make(map[string]string)
+	if flags.NArg() == 1 {
+		var err error
+		files, err = filepath.Glob(filepath.Join(flags.Arg(0), "*.txt"))
+		if err != nil {
+			Exit(fmt.Sprint("Error reading the directory: ", err))
+		}
+		if len(files) == 0 {
+			Exit(fmt.Sprintf("No colony files (*.txt) found in '%v'", flags.Arg(0)))
+		}
+		if expected, err = readExpectedList(filepath.Join(flags.Arg(0), "expected.list")); err != nil {
+			Exit(fmt.Sprint("Error reading the expected results: ", err))
+		}
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FILE\tANTS\tROOMS\tTURNS\tEXPECTED\tTIME\tSTATUS")
+
+	regressions := 0
+	for _, file := range files {
+		result := solveBatchFile(file)
+		if value, ok := expected[filepath.Base(file)]; ok {
+			result.expected = value
+		}
+		status := result.status()
+
+		// Keeping or checking the exact moves next to the colony
+		goldenFile := strings.TrimSuffix(file, ".txt") + ".golden"
+		if *update {
+			if err := os.WriteFile(goldenFile, []byte(result.goldenText()), 0o644); err != nil {
+				Exit(fmt.Sprint("Error writing the golden file: ", err))
+			}
+		} else if *golden && strings.HasPrefix(status, "ok") {
+			status = result.compareGolden(goldenFile)
+		}
+		if strings.HasPrefix(status, "REGRESSION") {
+			regressions++
+		}
+
+		turns := "-"
+		if result.err == nil {
+			turns = strconv.Itoa(result.turns)
+		}
+		expectedTurns := result.expected
+		if expectedTurns == "" {
+			expectedTurns = "-"
+		}
+		fmt.Fprintf(table, "%s\t%d\t%d\t%s\t%s\t%v\t%s\n", filepath.Base(file), result.ants, result.rooms,
+			turns, expectedTurns, result.elapsed.Round(time.Microsecond), status)
+	}
+
+	// Generated colonies have no expected turns, their solutions only have to keep the rules
+	for seed := int64(1); seed <= int64(*random); seed++ {
+		var result batchResult
+		colony, err := lemin.RandomColony(seed)
+		if err != nil {
+			result.err = err
+		} else {
+			start := time.Now()
+			result.solve(colony)
+			result.elapsed = time.Since(start)
+		}
+		status := result.status()
+		if strings.HasPrefix(status, "REGRESSION") {
+			regressions++
+		}
+		fmt.Fprintf(table, "random-%d\t%d\t%d\t%d\t-\t%v\t%s\n", seed, result.ants, result.rooms,
+			result.turns, result.elapsed.Round(time.Microsecond), status)
+	}
+	table.Flush()
+
+	total := len(files) + *random
+	if regressions > 0 {
+		Exit(fmt.Sprintf("\n%d of %d colonies regressed", regressions, total))
+	}
+	fmt.Printf("\nAll %d colonies passed\n", total)
+}
+
+// Parsing and solving one colony file and reading its expected result
+func solveBatchFile(file string) (result batchResult) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.expected = expectedTurns(content)
+
+	// Timing the parsing and the solving, also when they fail
+	start := time.Now()
+	defer func() { result.elapsed = time.Since(start) }()
+
+	colony, err := lemin.Parse(bytes.NewReader(content))
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.solve(colony)
+	return result
+}
+
+// Solving the colony and checking the properties of the solution
+func (r *batchResult) solve(colony *lemin.Colony) {
+	r.ants, r.rooms = colony.NumAnts, len(colony.Rooms)
+
+	solution, err := lemin.Solve(colony, lemin.Options{})
+	if errors.Is(err, lemin.ErrNoPath) {
+		r.err = errors.New("no valid combinations")
+		return
+	} else if err != nil {
+		r.err = err
+		return
+	}
+	r.turns = len(solution.Turns)
+	r.moves = solution.Turns
+	r.broken = lemin.CheckSolution(colony, solution)
+}
+
+// Moves of the colony in the output format, or the error for rejected colonies
+func (r batchResult) goldenText() string {
+	if r.err != nil {
+		return "ERROR: " + r.err.Error() + "\n"
+	}
+	var text strings.Builder
+	for i, turn := range r.moves {
+		fmt.Fprintln(&text, lemin.FormatTurn(i+1, turn))
+	}
+	return text.String()
+}
+
+// Comparing the moves to the golden file and telling the first line that differs
+func (r batchResult) compareGolden(goldenFile string) string {
+	content, err := os.ReadFile(goldenFile)
+	if err != nil {
+		return "REGRESSION: no golden file, run with -update"
+	}
+	want := strings.Split(string(content), "\n")
+	got := strings.Split(r.goldenText(), "\n")
+	for i := 0; i < len(want) || i < len(got); i++ {
+		if i >= len(want) || i >= len(got) || want[i] != got[i] {
+			return fmt.Sprintf("REGRESSION: line %d differs from %s", i+1, filepath.Base(goldenFile))
+		}
+	}
+	return "ok (golden)"
+}
+
+// Reading the "expected.list" file of a directory: every line has a colony file name and its expected turns
+// or "error", lines starting with # are comments. A missing file means no expectations.
+func readExpectedList(file string) (map[string]string, error) {
+	expected := make(map[string]string)
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return expected, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	lines := lemin.NewLineScanner(bytes.NewReader(content))
+	for lineNum := 1; lines.Scan(); lineNum++ {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s line %d: expected a file name and a result, got '%v'", filepath.Base(file), lineNum, line)
+		}
+		expected[fields[0]] = fields[1]
+	}
+	return expected, nil
+}
+
+// Finding the "# expected: N" (or "# expected: error") comment of the colony
+func expectedTurns(content []byte) string {
+	lines := lemin.NewLineScanner(bytes.NewReader(content))
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if value, found := strings.CutPrefix(line, "# expected:"); found {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// Comparing the result to the expected one: a solution breaking the rules, more turns than expected,
+// an unexpected error or a colony that should have been rejected are regressions
+func (r batchResult) status() string {
+	switch {
+	case r.broken != nil:
+		return "REGRESSION: " + r.broken.Error()
+	case r.expected == "error" && r.err != nil:
+		return "ok (rejected: " + r.err.Error() + ")"
+	case r.expected == "error":
+		return "REGRESSION: expected the colony to be rejected"
+	case r.err != nil && r.expected != "":
+		return "REGRESSION: " + r.err.Error()
+	case r.err != nil:
+		return "error: " + r.err.Error()
+	case r.expected == "":
+		return "ok"
+	}
+
+	expected, err := strconv.Atoi(r.expected)
+	if err != nil {
+		return fmt.Sprintf("REGRESSION: invalid expected value '%v'", r.expected)
+	}
+	if r.turns > expected {
+		return fmt.Sprintf("REGRESSION: %d turns over", r.turns-expected)
+	}
+	return "ok"
+}
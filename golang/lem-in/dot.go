@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"lemin/lemin"
+)
+
+// Colours of the chosen paths, reused when there are more paths
+var pathColours = []string{"#e6194b", "#3cb44b", "#4363d8", "#f58231", "#911eb4", "#42d4f4", "#f032e6", "#9a6324"}
+
+// Room coordinates are multiplied by this to get points in the drawing
+const dotScale = 72
+
+// Writing the colony as a Graphviz DOT graph to the given file, "-" writes to the standard output
+func writeDOT(fileName string, data *lemin.Colony, solution *lemin.Solution) error {
+	if fileName == "-" {
+		return PrintDOT(os.Stdout, data, solution)
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if err := PrintDOT(file, data, solution); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Print the colony as an undirected DOT graph: rooms are pinned to their coordinates with the neato layout,
+// every chosen path has its own colour and its tunnels tell how many ants walk them
+func PrintDOT(w io.Writer, data *lemin.Colony, solution *lemin.Solution) error {
+	out := bufio.NewWriter(w)
+
+	// Path and ant count of every tunnel on the chosen paths, a tunnel shared by several paths takes the colour of the
+	// first one and counts the ants of all of them
+	antsPerPath := make([]int, len(solution.Paths))
+	for _, path := range solution.Assignment {
+		if path >= 0 {
+			antsPerPath[path]++
+		}
+	}
+	tunnelPath := make(map[string]int)
+	tunnelAnts := make(map[string]int)
+	for i, path := range solution.Paths {
+		for j := 1; j < len(path); j++ {
+			for _, tunnel := range []string{path[j-1] + "-" + path[j], path[j] + "-" + path[j-1]} {
+				if tunnelPath[tunnel] == 0 {
+					tunnelPath[tunnel] = i + 1 // 0 means not on a path
+				}
+				tunnelAnts[tunnel] += antsPerPath[i]
+			}
+		}
+	}
+
+	fmt.Fprintln(out, "graph colony {")
+	fmt.Fprintln(out, "  layout=neato;")
+	fmt.Fprintln(out, "  node [shape=circle, fontsize=10];")
+	fmt.Fprintln(out, "  edge [color=\"#999999\"];")
+
+	// Rooms in a stable order, the y axis points down like in the room coordinates
+	var names []string
+	for name := range data.Rooms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		room := data.Rooms[name]
+		attributes := fmt.Sprintf("pos=\"%d,%d!\"", room.X*dotScale, -room.Y*dotScale)
+		switch {
+		case data.IsStart(name):
+			attributes += fmt.Sprintf(", shape=doublecircle, style=filled, fillcolor=\"#b7e4c7\", xlabel=\"start (%d)\"", data.AntsAt(name))
+		case data.IsEnd(name):
+			attributes += ", shape=doublecircle, style=filled, fillcolor=\"#f4acb7\", xlabel=\"end\""
+		case room.Capacity > 1:
+			attributes += fmt.Sprintf(", xlabel=\"capacity %d\"", room.Capacity)
+		}
+		fmt.Fprintf(out, "  %q [%s];\n", name, attributes)
+	}
+
+	// Every tunnel once, the ones on a path in the colour of the path and labelled with its ants
+	for _, name := range names {
+		for _, linked := range data.Tunnels[name] {
+			if name > linked {
+				continue
+			}
+			var attributes []string
+			label := ""
+			if path := tunnelPath[name+"-"+linked]; path > 0 {
+				attributes = append(attributes, fmt.Sprintf("color=%q", pathColours[(path-1)%len(pathColours)]), "penwidth=2")
+				label = fmt.Sprintf("%d ants", tunnelAnts[name+"-"+linked])
+				if tunnelAnts[name+"-"+linked] == 1 {
+					label = "1 ant"
+				}
+			}
+			if length := data.TunnelLength(name, linked); length > 1 {
+				if label != "" {
+					label += ", "
+				}
+				label += fmt.Sprintf("%d turns", length)
+			}
+			if label != "" {
+				attributes = append(attributes, fmt.Sprintf("label=%q", label))
+			}
+
+			if len(attributes) > 0 {
+				fmt.Fprintf(out, "  %q -- %q [%s];\n", name, linked, strings.Join(attributes, ", "))
+			} else {
+				fmt.Fprintf(out, "  %q -- %q;\n", name, linked)
+			}
+		}
+	}
+
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
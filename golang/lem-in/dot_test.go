@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"lemin/lemin"
+)
+
+// A tunnel on several paths is labelled with the ants of all of them
+func TestPrintDOTSharedTunnel(t *testing.T) {
+	input := "3\n##start 2\ns1 0 0\n##start 1\ns2 0 2\na 1 1\n##end\ne 2 1\ns1-a\ns2-a\na-e\n"
+	colony, err := lemin.ParseWithOptions(strings.NewReader(input), lemin.ParseOptions{Multi: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution, err := lemin.Solve(colony, lemin.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := PrintDOT(&out, colony, solution); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"a" -- "s1" [color="#e6194b", penwidth=2, label="2 ants"]`, `"a" -- "s2" [color="#3cb44b", penwidth=2, label="1 ant"]`, `"a" -- "e" [color="#e6194b", penwidth=2, label="3 ants"]`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %s in\n%s", want, out.String())
+		}
+	}
+}
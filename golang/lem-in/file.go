@@ -1,33 +1,59 @@
 package main
 
 import (
-	"bufio"
-	"fmt"
+	"bytes"
+	"io"
 	"os"
 )
 
-// Reading the file contents and stores each line to a []string
-func fileContents(fileName string) ([]string, error) {
+// Colony input that is read twice: once while parsing and once when printing it back
+type colonySource struct {
+	file   *os.File
+	buffer *bytes.Buffer // Copy of the content when the input cannot be rewound (stdin, pipes)
+}
+
+// Opening the colony file from the given path, "-" reads the colony from the standard input
+func openColony(fileName string) (*colonySource, error) {
+	if fileName == "-" {
+		return &colonySource{file: os.Stdin, buffer: &bytes.Buffer{}}, nil
+	}
 
-	file, err := os.Open("examples/" + fileName)
+	file, err := os.Open(fileName)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	// Reading the file line by line
-	var fileContent []string
-	line := bufio.NewScanner(file)
-	for line.Scan() {
-		fileContent = append(fileContent, line.Text())
+	// Only regular files can be rewound, everything else is copied while parsing
+	source := &colonySource{file: file}
+	if info, err := file.Stat(); err != nil || !info.Mode().IsRegular() {
+		source.buffer = &bytes.Buffer{}
 	}
-	if err := line.Err(); err != nil {
-		return nil, err
+	return source, nil
+}
+
+// Reader for parsing the colony
+func (s *colonySource) Reader() io.Reader {
+	if s.buffer != nil {
+		return io.TeeReader(s.file, s.buffer)
 	}
+	return s.file
+}
 
-	if len(fileContent) == 0 {
-		return nil, fmt.Errorf("file is empty")
+// Reader for the original content after parsing
+func (s *colonySource) Content() (io.Reader, error) {
+	if s.buffer != nil {
+		return s.buffer, nil
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
 	}
+	return s.file, nil
+}
 
-	return fileContent, nil
+// Closing the colony file, the standard input is left open
+func (s *colonySource) Close() error {
+	if s.file == os.Stdin {
+		return nil
+	}
+	return s.file.Close()
 }
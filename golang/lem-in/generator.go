@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"lemin/lemin"
+)
+
+// Running the generate subcommand and printing the colony to the standard output
+func runGenerate(args []string) {
+	options := lemin.GeneratorOptions{}
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	flags.IntVar(&options.Rooms, "rooms", 20, "number of rooms including start and end")
+	flags.IntVar(&options.Ants, "ants", 10, "number of ants")
+	flags.IntVar(&options.Routes, "routes", 2, "number of guaranteed disjoint routes from start to end")
+	flags.Float64Var(&options.Density, "density", 0.5, "extra random tunnels per room")
+	flags.IntVar(&options.Width, "width", 0, "width of the coordinate grid (0 = square grid)")
+	flags.Int64Var(&options.Seed, "seed", 1, "seed for the random generator")
+	flags.StringVar(&options.Trap, "trap", "", "trap topology: detour, bottleneck or deadend")
+	flags.Parse(args)
+
+	colony, err := lemin.Generate(options)
+	if err != nil {
+		Exit(fmt.Sprint("ERROR: ", err))
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	lemin.WriteColony(out, colony)
+}
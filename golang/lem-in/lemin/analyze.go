@@ -0,0 +1,164 @@
+package lemin
+
+import (
+	"context"
+	"sort"
+)
+
+// Graph properties of a colony, useful to find out why no path reaches the end
+type Analysis struct {
+	Rooms         int
+	Tunnels       int
+	Components    [][]string // Connected groups of rooms, largest first, rooms sorted by name
+	Unreachable   []string   // Rooms that cannot be reached from any start room
+	DeadEnds      []string   // Rooms with at most one tunnel, start and end rooms excluded
+	Bottlenecks   []string   // Rooms that every path from the start to the end goes through
+	DisjointPaths int        // Maximum number of paths that can be used at the same time, whatever the number of ants
+	ShortestPath  []string   // Path from the start to the end taking the fewest turns, nil when there is none
+}
+
+// Analyze collects the graph properties of the colony
+func Analyze(colony *Colony) *Analysis {
+	names := make([]string, 0, len(colony.Rooms))
+	for name := range colony.Rooms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	analysis := &Analysis{Rooms: len(names)}
+	for _, name := range names {
+		degree := len(colony.Tunnels[name])
+		analysis.Tunnels += degree
+		if degree <= 1 && !colony.IsStart(name) && !colony.IsEnd(name) {
+			analysis.DeadEnds = append(analysis.DeadEnds, name)
+		}
+	}
+	analysis.Tunnels /= 2 // Every tunnel is stored in both rooms
+
+	// Grouping the rooms, the rooms outside of the groups of the start rooms are unreachable
+	component := make(map[string]int)
+	for _, name := range names {
+		if _, seen := component[name]; seen {
+			continue
+		}
+		group := colony.reachable([]string{name})
+		for _, room := range group {
+			component[room] = len(analysis.Components)
+		}
+		sort.Strings(group)
+		analysis.Components = append(analysis.Components, group)
+	}
+	reached := make(map[string]bool)
+	for _, room := range colony.reachable(colony.StartRooms()) {
+		reached[room] = true
+	}
+	for _, name := range names {
+		if !reached[name] {
+			analysis.Unreachable = append(analysis.Unreachable, name)
+		}
+	}
+	sort.SliceStable(analysis.Components, func(i, j int) bool {
+		return len(analysis.Components[i]) > len(analysis.Components[j])
+	})
+
+	analysis.Bottlenecks = colony.bottlenecks()
+
+	// The first path of the flow is the cheapest one and the last set has the most paths.
+	// The flow sends at most one path per ant, with more ants than tunnels only the graph limits the paths.
+	unlimited := *colony
+	unlimited.NumAnts = analysis.Tunnels + 1
+	unlimited.StartAnts = make(map[string]int)
+	for _, start := range colony.StartRooms() {
+		unlimited.StartAnts[start] = unlimited.NumAnts
+	}
+	pathSets, _ := findPathSets(context.Background(), &unlimited, 0)
+	if len(pathSets) > 0 {
+		analysis.DisjointPaths = len(pathSets[len(pathSets)-1])
+		analysis.ShortestPath = pathSets[0][0]
+	}
+	return analysis
+}
+
+// Rooms that can be reached from the given rooms through the tunnels
+func (c *Colony) reachable(from []string) []string {
+	seen := make(map[string]bool)
+	var rooms []string
+	queue := []string{}
+	for _, room := range from {
+		if _, exists := c.Rooms[room]; exists && !seen[room] {
+			seen[room] = true
+			queue = append(queue, room)
+		}
+	}
+	for len(queue) > 0 {
+		room := queue[0]
+		queue = queue[1:]
+		rooms = append(rooms, room)
+		for _, linked := range c.Tunnels[room] {
+			if !seen[linked] {
+				seen[linked] = true
+				queue = append(queue, linked)
+			}
+		}
+	}
+	return rooms
+}
+
+// Finding the articulation points that separate the start rooms from the end rooms (Tarjan).
+// The start rooms hang from a virtual root and the end rooms lead to a virtual target, a room r
+// is a bottleneck when the target is in the subtree of a child c of r with low[c] >= disc[r].
+func (c *Colony) bottlenecks() []string {
+	const root, target = "", "\x00"
+	neighbours := func(room string) []string {
+		switch room {
+		case root:
+			return c.StartRooms()
+		case target:
+			return c.EndRooms()
+		}
+		linked := c.Tunnels[room]
+		if c.IsStart(room) {
+			linked = append([]string{root}, linked...)
+		}
+		if c.IsEnd(room) {
+			linked = append([]string{target}, linked...)
+		}
+		return linked
+	}
+
+	disc := make(map[string]int)
+	low := make(map[string]int)
+	parent := make(map[string]string)
+	var visit func(room string)
+	visit = func(room string) {
+		disc[room] = len(disc) + 1
+		low[room] = disc[room]
+		for _, linked := range neighbours(room) {
+			if _, seen := disc[linked]; !seen {
+				parent[linked] = room
+				visit(linked)
+				low[room] = min(low[room], low[linked])
+			} else if linked != parent[room] {
+				low[room] = min(low[room], disc[linked])
+			}
+		}
+	}
+	visit(root)
+	if _, reached := disc[target]; !reached {
+		return nil // Nothing to separate
+	}
+
+	// Walking up the tree from the target, the start and end rooms themselves are not counted
+	var rooms []string
+	for child := target; child != root; child = parent[child] {
+		room := parent[child]
+		if room != root && !c.IsStart(room) && !c.IsEnd(room) && low[child] >= disc[room] {
+			rooms = append(rooms, room)
+		}
+	}
+	// Listing the bottlenecks from the start to the end
+	for i, j := 0, len(rooms)-1; i < j; i, j = i+1, j-1 {
+		rooms[i], rooms[j] = rooms[j], rooms[i]
+	}
+	return rooms
+}
@@ -0,0 +1,26 @@
+package lemin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The number of disjoint paths is a property of the graph, one ant does not lower it
+func TestAnalyzeDisjointPaths(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "examples", "example01.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ants := range []string{"1", "10"} {
+		_, rest, _ := strings.Cut(string(content), "\n")
+		colony, err := Parse(strings.NewReader(ants + "\n" + rest))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if paths := Analyze(colony).DisjointPaths; paths != 3 {
+			t.Errorf("%s ants: got %d disjoint paths, want 3", ants, paths)
+		}
+	}
+}
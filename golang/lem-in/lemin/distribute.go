@@ -0,0 +1,87 @@
+package lemin
+
+import (
+	"math"
+	"sort"
+)
+
+// Turns the ants need on k paths of total length S (in turns). A path of length l carries T-l+1 ants in T turns,
+// so all ants are through once ants <= k*T - S + k, that is after T = ceil((ants + S - k) / k) turns.
+func turnsOnPaths(ants, total, k int) int {
+	return (ants + total - 1) / k
+}
+
+// Finding the optimal number of ants for every path and the turns they need, without simulating.
+// With the j shortest paths all ants are through after T_j = turnsOnPaths turns. Using j paths only makes sense when
+// the j-th path gets an ant (T_j >= l_j), the lowest such T_j is the answer. The ants of every start
+// room are distributed over the paths leaving it, paths that get no ants are left unused.
+// The turns are math.MaxInt when a start room with ants has no path.
+func distributeAnts(colony *Colony, paths [][]string) ([]int, int) {
+	counts := make([]int, len(paths))
+	if len(paths) == 0 {
+		return counts, math.MaxInt
+	}
+
+	turns := 0
+	for _, start := range colony.StartRooms() {
+		ants := colony.AntsAt(start)
+		if ants == 0 {
+			continue
+		}
+
+		// Paths of the start room from the shortest to the longest
+		var group []int
+		for i, path := range paths {
+			if path[0] == start {
+				group = append(group, i)
+			}
+		}
+		if len(group) == 0 {
+			return counts, math.MaxInt
+		}
+		sort.SliceStable(group, func(a, b int) bool {
+			return colony.PathLength(paths[group[a]]) < colony.PathLength(paths[group[b]])
+		})
+
+		// Trying every number of the shortest paths
+		best, used, total := math.MaxInt, 0, 0
+		for j, path := range group {
+			length := colony.PathLength(paths[path])
+			total += length
+			k := j + 1
+			t := turnsOnPaths(ants, total, k)
+			if t < length {
+				break // The longer paths would not get any ants either
+			}
+			if t < best {
+				best, used = t, k
+			}
+		}
+
+		// Filling the used paths up to the turn count and taking the extra ants off the longest ones
+		extra := -ants
+		for _, path := range group[:used] {
+			counts[path] = best - colony.PathLength(paths[path]) + 1
+			extra += counts[path]
+		}
+		for i := used - 1; i >= 0 && extra > 0; i-- {
+			counts[group[i]]--
+			extra--
+		}
+		turns = max(turns, best)
+	}
+	return counts, turns
+}
+
+// Counting the turns needed to move all ants through the given paths with the optimal distribution
+func countTurns(colony *Colony, paths [][]string) int {
+	_, turns := distributeAnts(colony, paths)
+	return turns
+}
+
+// Counting how many ants every path gets with the optimal distribution,
+// false when some start room with ants has no path
+func countAntsPerPath(colony *Colony, paths [][]string) ([]int, bool) {
+	counts, turns := distributeAnts(colony, paths)
+	return counts, turns != math.MaxInt
+}
@@ -0,0 +1,302 @@
+package lemin
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Settings for generating a colony
+type GeneratorOptions struct {
+	Rooms   int     // Total number of rooms including start and end
+	Ants    int     // Number of ants
+	Routes  int     // Number of disjoint routes from start to end that are guaranteed to exist
+	Density float64 // Extra random tunnels per room
+	Width   int     // Width of the coordinate grid, 0 means square grid
+	Seed    int64   // Seed for the random generator
+	Trap    string  // Trap topology: "", "detour", "bottleneck" or "deadend"
+}
+
+// Colony generator that remembers what it has built so far
+type generator struct {
+	colony *Colony
+	rng    *rand.Rand
+	seen   map[string]bool // Tunnels already added, in both directions
+	next   int             // Number of the last generated room
+}
+
+// Generate builds a valid colony with the given options
+func Generate(options GeneratorOptions) (*Colony, error) {
+	if options.Ants <= 0 {
+		return nil, fmt.Errorf("number of ants must be positive")
+	}
+	if options.Routes <= 0 {
+		return nil, fmt.Errorf("number of routes must be positive")
+	}
+	if options.Density < 0 {
+		return nil, fmt.Errorf("density cannot be negative")
+	}
+	// Every route needs at least one room of its own
+	if options.Rooms < options.Routes+2 {
+		return nil, fmt.Errorf("at least %d rooms are needed for %d routes", options.Routes+2, options.Routes)
+	}
+
+	g := &generator{
+		colony: &Colony{
+			NumAnts:   options.Ants,
+			StartRoom: "start",
+			EndRoom:   "end",
+			Rooms:     make(map[string]Room),
+			Tunnels:   make(map[string][]string),
+		},
+		rng:  rand.New(rand.NewSource(options.Seed)),
+		seen: make(map[string]bool),
+	}
+	g.colony.Rooms["start"] = Room{Name: "start"}
+	g.colony.Rooms["end"] = Room{Name: "end"}
+
+	free := options.Rooms - 2 // Rooms left to place
+
+	// Rooms reserved for the trap topology
+	trapRooms := 0
+	switch options.Trap {
+	case "":
+	case "detour", "deadend":
+		trapRooms = free / 4
+	case "bottleneck":
+		trapRooms = 1
+	default:
+		return nil, fmt.Errorf("unknown trap '%v'", options.Trap)
+	}
+	if free-trapRooms < options.Routes {
+		trapRooms = free - options.Routes
+	}
+	free -= trapRooms
+
+	// Half of the remaining rooms form the disjoint routes, the rest are filler rooms
+	routeRooms := free / 2
+	if routeRooms < options.Routes {
+		routeRooms = options.Routes
+	}
+	routes := make([][]string, options.Routes)
+	for i := range routes {
+		length := routeRooms / options.Routes
+		if i < routeRooms%options.Routes {
+			length++
+		}
+		route := []string{"start"}
+		for j := 0; j < length; j++ {
+			room := g.newRoom()
+			g.addTunnel(route[len(route)-1], room)
+			route = append(route, room)
+		}
+		g.addTunnel(route[len(route)-1], "end")
+		routes[i] = append(route, "end")
+	}
+	free -= routeRooms
+
+	// Filler rooms hang on random existing rooms so that the colony stays connected
+	for ; free > 0; free-- {
+		existing := g.randomRoom()
+		room := g.newRoom()
+		g.addTunnel(existing, room)
+	}
+
+	// Extra tunnels between random rooms, the start and end keep only their route tunnels
+	extra := int(options.Density * float64(options.Rooms))
+	for tries := 0; extra > 0 && tries < extra*10; tries++ {
+		if g.addTunnel(g.randomRoom(), g.randomRoom()) {
+			extra--
+		}
+	}
+
+	switch options.Trap {
+	case "detour":
+		g.addDetour(trapRooms)
+	case "bottleneck":
+		if trapRooms > 0 {
+			g.addBottleneck(routes)
+		}
+	case "deadend":
+		g.addDeadEnds(routes, trapRooms)
+	}
+
+	g.placeRooms(options.Width)
+	return g.colony, nil
+}
+
+// Adding a new room to the colony and returning its name
+func (g *generator) newRoom() string {
+	g.next++
+	name := fmt.Sprintf("r%d", g.next)
+	g.colony.Rooms[name] = Room{Name: name}
+	return name
+}
+
+// Picking a random room that is not the start or the end
+func (g *generator) randomRoom() string {
+	return fmt.Sprintf("r%d", g.rng.Intn(g.next)+1)
+}
+
+// Adding a tunnel between two rooms, returns false for self-links and duplicates
+func (g *generator) addTunnel(room1, room2 string) bool {
+	if room1 == room2 || g.seen[room1+"-"+room2] {
+		return false
+	}
+	g.seen[room1+"-"+room2], g.seen[room2+"-"+room1] = true, true
+	g.colony.Tunnels[room1] = append(g.colony.Tunnels[room1], room2)
+	g.colony.Tunnels[room2] = append(g.colony.Tunnels[room2], room1)
+	return true
+}
+
+// Detour trap: one extra route from start to end that is much longer than the others
+func (g *generator) addDetour(length int) {
+	if length == 0 {
+		return
+	}
+	previous := "start"
+	for i := 0; i < length; i++ {
+		room := g.newRoom()
+		g.addTunnel(previous, room)
+		previous = room
+	}
+	g.addTunnel(previous, "end")
+}
+
+// Bottleneck trap: a hub room that gives every route a shortcut, so the shortest path blocks all the others
+func (g *generator) addBottleneck(routes [][]string) {
+	hub := g.newRoom()
+	for _, route := range routes {
+		g.addTunnel(route[1], hub)
+		g.addTunnel(hub, route[len(route)-2])
+	}
+}
+
+// Dead end trap: chains of rooms branching from the routes that lead nowhere
+func (g *generator) addDeadEnds(routes [][]string, rooms int) {
+	for rooms > 0 {
+		route := routes[g.rng.Intn(len(routes))]
+		previous := route[1+g.rng.Intn(len(route)-2)]
+		length := 1 + g.rng.Intn(int(math.Min(float64(rooms), 5)))
+		for i := 0; i < length; i++ {
+			room := g.newRoom()
+			g.addTunnel(previous, room)
+			previous = room
+		}
+		rooms -= length
+	}
+}
+
+// Giving every room a unique position on the grid, start on the left and end on the right
+func (g *generator) placeRooms(width int) {
+	rooms := len(g.colony.Rooms)
+	if width <= 0 {
+		width = int(math.Ceil(math.Sqrt(float64(rooms))))
+	}
+	height := (rooms + width - 1) / width
+
+	start := g.colony.Rooms["start"]
+	start.X, start.Y = 0, height/2
+	g.colony.Rooms["start"] = start
+	end := g.colony.Rooms["end"]
+	end.X, end.Y = width+1, height/2
+	g.colony.Rooms["end"] = end
+
+	// The other rooms fill the grid between them in random order
+	cells := g.rng.Perm(width * height)
+	for i := 1; i <= g.next; i++ {
+		name := fmt.Sprintf("r%d", i)
+		room := g.colony.Rooms[name]
+		room.X, room.Y = cells[i-1]%width+1, cells[i-1]/width
+		g.colony.Rooms[name] = room
+	}
+}
+
+// WriteColony writes the colony in the project's format: ants, rooms and tunnels
+func WriteColony(w io.Writer, colony *Colony) {
+	fmt.Fprintln(w, colony.NumAnts)
+
+	start, end := colony.Rooms[colony.StartRoom], colony.Rooms[colony.EndRoom]
+	fmt.Fprintf(w, "##start\n%s %d %d\n", start.Name, start.X, start.Y)
+	fmt.Fprintf(w, "##end\n%s %d %d\n", end.Name, end.X, end.Y)
+
+	// Other rooms in natural order (r2 before r10)
+	var names []string
+	for name := range colony.Rooms {
+		if name != colony.StartRoom && name != colony.EndRoom {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) < len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		room := colony.Rooms[name]
+		if room.Capacity > 1 {
+			fmt.Fprintf(w, "##capacity %d\n", room.Capacity)
+		}
+		fmt.Fprintf(w, "%s %d %d\n", room.Name, room.X, room.Y)
+	}
+
+	// Every tunnel is written once, from the room that comes first
+	written := make(map[string]bool)
+	for _, name := range append([]string{colony.StartRoom, colony.EndRoom}, names...) {
+		for _, linked := range colony.Tunnels[name] {
+			if written[linked+"-"+name] {
+				continue
+			}
+			written[name+"-"+linked] = true
+			if length := colony.TunnelLength(name, linked); length > 1 {
+				fmt.Fprintf(w, "##length %d\n", length)
+			}
+			fmt.Fprintf(w, "%s-%s\n", name, linked)
+		}
+	}
+}
+
+// RandomColony generates a colony from the seed with random size, ants, routes, density and trap.
+// Some rooms hold more ants and some tunnels are longer, the same seed always gives the same colony.
+func RandomColony(seed int64) (*Colony, error) {
+	rng := rand.New(rand.NewSource(seed))
+	traps := []string{"", "detour", "bottleneck", "deadend"}
+	rooms := 4 + rng.Intn(100)
+	colony, err := Generate(GeneratorOptions{
+		Rooms:   rooms,
+		Ants:    1 + rng.Intn(50),
+		Routes:  1 + rng.Intn(min(4, rooms-2)),
+		Density: rng.Float64() * 2,
+		Seed:    seed,
+		Trap:    traps[rng.Intn(len(traps))],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Going through the rooms in a stable order so the same seed gives the same colony
+	names := make([]string, 0, len(colony.Rooms))
+	for name := range colony.Rooms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if colony.Lengths == nil {
+		colony.Lengths = make(map[string]int)
+	}
+	for _, name := range names {
+		if room := colony.Rooms[name]; rng.Intn(5) == 0 && !colony.IsStart(name) && !colony.IsEnd(name) {
+			room.Capacity = 2 + rng.Intn(3)
+			colony.Rooms[name] = room
+		}
+		for _, linked := range colony.Tunnels[name] {
+			if name < linked && rng.Intn(5) == 0 {
+				length := 2 + rng.Intn(3)
+				colony.Lengths[name+"-"+linked], colony.Lengths[linked+"-"+name] = length, length
+			}
+		}
+	}
+	return colony, nil
+}
@@ -0,0 +1,82 @@
+package lemin
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the .golden files of the examples from the current moves")
+
+// Moves of the colony file in the format of the .golden files, or the error for rejected colonies
+func goldenText(file string) (string, error) {
+	content, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	colony, err := Parse(content)
+	if err != nil {
+		return "ERROR: " + err.Error() + "\n", nil
+	}
+	solution, err := Solve(colony, Options{})
+	if errors.Is(err, ErrNoPath) {
+		return "ERROR: no valid combinations\n", nil
+	} else if err != nil {
+		return "ERROR: " + err.Error() + "\n", nil
+	}
+
+	var text strings.Builder
+	for i, turn := range solution.Turns {
+		fmt.Fprintln(&text, FormatTurn(i+1, turn))
+	}
+	return text.String(), nil
+}
+
+// Solving every example and comparing the moves to its .golden file, "go test ./lemin -update" rewrites them
+func TestGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "examples", "*.txt"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no examples found: %v", err)
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			got, err := goldenText(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			goldenFile := strings.TrimSuffix(file, ".txt") + ".golden"
+			if *update {
+				if err := os.WriteFile(goldenFile, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("no golden file, run with -update: %v", err)
+			}
+			wantLines, gotLines := strings.Split(string(want), "\n"), strings.Split(got, "\n")
+			for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+				if i >= len(wantLines) || i >= len(gotLines) || wantLines[i] != gotLines[i] {
+					t.Fatalf("line %d differs from %s:\ngot:  %q\nwant: %q", i+1, filepath.Base(goldenFile),
+						lineAt(gotLines, i), lineAt(wantLines, i))
+				}
+			}
+		})
+	}
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return "<end of file>"
+}
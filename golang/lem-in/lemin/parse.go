@@ -0,0 +1,378 @@
+package lemin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Room of the colony with its coordinates
+type Room struct {
+	Name     string
+	X        int
+	Y        int
+	Capacity int // Number of ants the room can hold, set with ##capacity (0 means the default of one ant)
+}
+
+// Colony parsed from the input: ants, rooms and the tunnels between them
+type Colony struct {
+	NumAnts   int
+	StartRoom string
+	EndRoom   string
+	Rooms     map[string]Room
+	Tunnels   map[string][]string
+	Lengths   map[string]int // Turns needed to cross a tunnel "room1-room2" (both directions), set with ##length
+	Starts    []string       // All start rooms in the order of declaration, StartRoom is the first one
+	Ends      []string       // All end rooms in the order of declaration, EndRoom is the first one
+	StartAnts map[string]int // Ants waiting in every start room, set with "##start N" when there are several
+}
+
+// Settings for parsing a colony
+type ParseOptions struct {
+	Multi bool // Allow several ##start and ##end rooms, "##start N" puts N of the ants in the start room
+}
+
+// Parse reads the colony line by line from the reader as per structs and returns it.
+// Invalid input is reported with a *ParseError.
+func Parse(r io.Reader) (*Colony, error) {
+	return ParseWithOptions(r, ParseOptions{})
+}
+
+// ParseWithOptions is Parse with the extensions enabled by the options
+func ParseWithOptions(r io.Reader, options ParseOptions) (*Colony, error) {
+	// creating dynamic data
+	colony := &Colony{
+		Rooms:     make(map[string]Room),
+		Tunnels:   make(map[string][]string),
+		Lengths:   make(map[string]int),
+		StartAnts: make(map[string]int),
+	}
+
+	scanner := NewLineScanner(r)
+
+	// Getting the number of ants
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, &ParseError{Msg: "file is empty"}
+	}
+	numAnts, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || numAnts <= 0 {
+		return nil, lineError(1, "invalid number of ants '%v'", scanner.Text())
+	}
+	colony.NumAnts = numAnts
+
+	// Parsing rooms and tunnels
+	var command string               // "##start" or "##end" waiting for its room
+	var commandLine int              // line of the pending command
+	var startAnts int                // ants of a pending "##start N" command
+	var tunnelsStarted bool          // rooms cannot be declared after the first tunnel
+	var capacity, length int         // values of pending "##capacity N" and "##length N" commands
+	var capacityLine, lengthLine int // lines of the pending value commands
+	tunnelSeen := make(map[string]bool)
+
+	lineNum := 1 // The first line is the number of ants
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+		if line == "##start" || line == "##end" { // flagging that the next room is a starting or finishing room
+			if command != "" {
+				return nil, lineError(commandLine, "%s is not followed by a room", command)
+			}
+			command, commandLine, startAnts = line, lineNum, 0
+			continue
+		}
+		if keyword := strings.Fields(line)[0]; options.Multi && (keyword == "##start" || keyword == "##end") {
+			// "##start N" tells how many of the ants wait in the start room
+			if keyword == "##end" {
+				return nil, lineError(lineNum, "##end takes no value")
+			}
+			if command != "" {
+				return nil, lineError(commandLine, "%s is not followed by a room", command)
+			}
+			_, value, err := parseValueCommand(line)
+			if err != nil {
+				return nil, lineError(lineNum, "%v", err)
+			}
+			command, commandLine, startAnts = keyword, lineNum, value
+			continue
+		}
+		if keyword := strings.Fields(line)[0]; keyword == "##capacity" || keyword == "##length" {
+			name, value, err := parseValueCommand(line)
+			if err != nil {
+				return nil, lineError(lineNum, "%v", err)
+			}
+			if name == "##capacity" {
+				if capacity != 0 {
+					return nil, lineError(capacityLine, "##capacity is not followed by a room")
+				}
+				capacity, capacityLine = value, lineNum
+			} else {
+				if length != 0 {
+					return nil, lineError(lengthLine, "##length is not followed by a tunnel")
+				}
+				length, lengthLine = value, lineNum
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") { // Comments and unknown commands are ignored
+			continue
+		}
+
+		// Checking if the line defines a room (length of the slice is 3)
+		parts := strings.Fields(line)
+		if len(parts) == 3 {
+			name := parts[0]
+			if name[0] == 'L' || name[0] == '#' { // Name cannot start with a L or #
+				return nil, lineError(lineNum, "invalid room name '%v'", name)
+			}
+			if tunnelsStarted {
+				return nil, lineError(lineNum, "room '%v' declared after tunnels", name)
+			}
+			if _, exists := colony.Rooms[name]; exists {
+				return nil, lineError(lineNum, "duplicate room '%v'", name)
+			}
+			x, err1 := strconv.Atoi(parts[1])
+			y, err2 := strconv.Atoi(parts[2])
+			if err1 != nil || err2 != nil {
+				return nil, lineError(lineNum, "invalid room coordinates '%v %v'", parts[1], parts[2])
+			}
+			if length != 0 {
+				return nil, lineError(lengthLine, "##length is not followed by a tunnel")
+			}
+			room := Room{Name: name, X: x, Y: y, Capacity: capacity} // creating the room
+			colony.Rooms[name] = room                                // adding the room to the map
+			capacity = 0
+
+			switch command {
+			case "##start":
+				if colony.StartRoom != "" && !options.Multi {
+					return nil, lineError(commandLine, "several start rooms defined")
+				}
+				if colony.StartRoom == "" {
+					colony.StartRoom = name
+				}
+				colony.Starts = append(colony.Starts, name)
+				colony.StartAnts[name] = startAnts
+			case "##end":
+				if colony.EndRoom != "" && !options.Multi {
+					return nil, lineError(commandLine, "several end rooms defined")
+				}
+				if colony.EndRoom == "" {
+					colony.EndRoom = name
+				}
+				colony.Ends = append(colony.Ends, name)
+			}
+			command = ""
+			continue
+		}
+
+		if command != "" {
+			return nil, lineError(commandLine, "%s is not followed by a room", command)
+		}
+		if capacity != 0 {
+			return nil, lineError(capacityLine, "##capacity is not followed by a room")
+		}
+
+		// Checking if the line defines a connection (includes "-")
+		if len(parts) == 1 && strings.Contains(line, "-") {
+			connParts := strings.Split(line, "-")
+			if len(connParts) != 2 || connParts[0] == "" || connParts[1] == "" {
+				return nil, lineError(lineNum, "invalid connection '%v'", line)
+			}
+			room1, room2 := connParts[0], connParts[1]
+			for _, room := range connParts {
+				if _, exists := colony.Rooms[room]; !exists {
+					return nil, lineError(lineNum, "tunnel to unknown room '%v'", room)
+				}
+			}
+			if room1 == room2 {
+				return nil, lineError(lineNum, "room '%v' links to itself", room1)
+			}
+			if tunnelSeen[room1+"-"+room2] {
+				return nil, lineError(lineNum, "duplicate tunnel '%v'", line)
+			}
+			tunnelSeen[room1+"-"+room2], tunnelSeen[room2+"-"+room1] = true, true
+			tunnelsStarted = true
+			if length != 0 {
+				colony.Lengths[room1+"-"+room2], colony.Lengths[room2+"-"+room1] = length, length
+				length = 0
+			}
+
+			colony.Tunnels[room1] = append(colony.Tunnels[room1], room2) // adding the connection on both tunnel maps
+			colony.Tunnels[room2] = append(colony.Tunnels[room2], room1)
+			continue
+		}
+
+		return nil, lineError(lineNum, "unrecognized line '%v'", line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if command != "" {
+		return nil, lineError(commandLine, "%s is not followed by a room", command)
+	}
+	if capacity != 0 {
+		return nil, lineError(capacityLine, "##capacity is not followed by a room")
+	}
+	if length != 0 {
+		return nil, lineError(lengthLine, "##length is not followed by a tunnel")
+	}
+
+	// Checking if the star or end room is missing
+	if colony.StartRoom == "" {
+		return nil, &ParseError{Msg: "start room not defined"}
+	}
+	if colony.EndRoom == "" {
+		return nil, &ParseError{Msg: "end room not defined"}
+	}
+
+	// Ants are shared by the start rooms, a single start room holds all of them
+	if len(colony.Starts) == 1 && colony.StartAnts[colony.StartRoom] == 0 {
+		colony.StartAnts[colony.StartRoom] = colony.NumAnts
+	}
+	total := 0
+	for _, start := range colony.Starts {
+		if colony.StartAnts[start] == 0 {
+			return nil, &ParseError{Msg: fmt.Sprintf("start room '%v' needs its number of ants (##start N)", start)}
+		}
+		total += colony.StartAnts[start]
+	}
+	if total != colony.NumAnts {
+		return nil, &ParseError{Msg: fmt.Sprintf("start rooms hold %d ants instead of %d", total, colony.NumAnts)}
+	}
+
+	return colony, nil
+}
+
+// Parsing a command with a positive number value like "##capacity 3" or "##length 2"
+func parseValueCommand(line string) (string, int, error) {
+	parts := strings.Fields(line)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("%v needs one value", parts[0])
+	}
+	value, err := strconv.Atoi(parts[1])
+	if err != nil || value <= 0 {
+		return "", 0, fmt.Errorf("invalid %v value '%v'", parts[0], parts[1])
+	}
+	return parts[0], value, nil
+}
+
+// Start rooms of the colony, colonies built without Starts have only the StartRoom
+func (c *Colony) StartRooms() []string {
+	if len(c.Starts) == 0 {
+		return []string{c.StartRoom}
+	}
+	return c.Starts
+}
+
+// End rooms of the colony, colonies built without Ends have only the EndRoom
+func (c *Colony) EndRooms() []string {
+	if len(c.Ends) == 0 {
+		return []string{c.EndRoom}
+	}
+	return c.Ends
+}
+
+// Checking if the room is one of the start rooms
+func (c *Colony) IsStart(name string) bool {
+	for _, start := range c.StartRooms() {
+		if name == start {
+			return true
+		}
+	}
+	return false
+}
+
+// Checking if the room is one of the end rooms
+func (c *Colony) IsEnd(name string) bool {
+	for _, end := range c.EndRooms() {
+		if name == end {
+			return true
+		}
+	}
+	return false
+}
+
+// Number of ants waiting in the start room at the beginning
+func (c *Colony) AntsAt(start string) int {
+	if ants, ok := c.StartAnts[start]; ok {
+		return ants
+	}
+	if start == c.StartRoom {
+		return c.NumAnts
+	}
+	return 0
+}
+
+// Start room of ant n, ants are numbered from the first start room to the last one
+func (c *Colony) AntStart(ant int) string {
+	for _, start := range c.StartRooms() {
+		if ant <= c.AntsAt(start) {
+			return start
+		}
+		ant -= c.AntsAt(start)
+	}
+	return c.StartRoom
+}
+
+// Number of ants the room can hold at the same time, start and end can hold all of them
+func (c *Colony) RoomCapacity(name string) int {
+	if c.IsStart(name) || c.IsEnd(name) {
+		return c.NumAnts
+	}
+	if capacity := c.Rooms[name].Capacity; capacity > 0 {
+		return capacity
+	}
+	return 1
+}
+
+// Number of turns it takes to cross the tunnel between two rooms
+func (c *Colony) TunnelLength(room1, room2 string) int {
+	if length, ok := c.Lengths[room1+"-"+room2]; ok {
+		return length
+	}
+	return 1
+}
+
+// Number of turns it takes to walk the whole path
+func (c *Colony) PathLength(path []string) int {
+	length := 0
+	for i := 1; i < len(path); i++ {
+		length += c.TunnelLength(path[i-1], path[i])
+	}
+	return length
+}
+
+// Invalid colony input, Line is 0 when the problem is not on a single line
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// Creating an error that points to the line of the input where the problem is
+func lineError(lineNum int, format string, args ...interface{}) error {
+	return &ParseError{Line: lineNum, Msg: fmt.Sprintf(format, args...)}
+}
+
+// NewLineScanner creates a line scanner that also accepts the very long lines of generated colonies
+func NewLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return scanner
+}
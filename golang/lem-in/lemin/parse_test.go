@@ -0,0 +1,161 @@
+package lemin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Checking that the error is a *ParseError with the given line and message, or that there is no error when msg is empty
+func checkParseError(t *testing.T, err error, line int, msg string) {
+	t.Helper()
+	if msg == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("got error %v, want a *ParseError '%v'", err, msg)
+	}
+	if parseErr.Line != line || parseErr.Msg != msg {
+		t.Fatalf("got line %d '%v', want line %d '%v'", parseErr.Line, parseErr.Msg, line, msg)
+	}
+}
+
+func TestParseExamples(t *testing.T) {
+	tests := []struct {
+		file string
+		line int
+		msg  string // Empty for colonies that are accepted
+	}{
+		{"badexample00.txt", 1, "invalid number of ants '0'"},
+		{"badexample01.txt", 27, "room '3' links to itself"},
+		{"example00.txt", 0, ""},
+		{"example01.txt", 0, ""},
+		{"example02.txt", 0, ""},
+		{"example03.txt", 0, ""},
+		{"example04.txt", 0, ""},
+		{"example05.txt", 0, ""},
+		{"example06.txt", 0, ""},
+		{"example07.txt", 0, ""},
+		{"example08.txt", 0, ""}, // A long tunnel, the first turns have no moves
+		{"test2end.txt", 7, "several end rooms defined"},
+		{"test2start.txt", 4, "several start rooms defined"},
+		{"testbrokenpath.txt", 0, ""}, // Valid input, rejected by the solver
+		{"testbrokenpath01.txt", 0, ""},
+		{"testcomments.txt", 0, ""},
+		{"testendcomments.txt", 2, "##start is not followed by a room"},
+		{"testloop.txt", 0, ""},
+		{"teststartcomments.txt", 2, "##start is not followed by a room"},
+		{"teststartendinverted01.txt", 0, ""},
+		{"teststartendinverted02.txt", 0, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("..", "examples", test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			_, err = Parse(file)
+			checkParseError(t, err, test.line, test.msg)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		msg   string
+	}{
+		{"empty file", "", 0, "file is empty"},
+		{"empty first line", "\n##start\na 0 0\n", 1, "invalid number of ants ''"},
+		{"negative ants", "-3\n##start\na 0 0\n##end\nb 1 1\na-b\n", 1, "invalid number of ants '-3'"},
+		{"duplicate room", "1\n##start\na 0 0\na 1 1\n##end\nb 2 2\n", 4, "duplicate room 'a'"},
+		{"room starting with L", "1\n##start\nLa 0 0\n", 3, "invalid room name 'La'"},
+		{"invalid coordinates", "1\n##start\na x 0\n", 3, "invalid room coordinates 'x 0'"},
+		{"unknown tunnel room", "1\n##start\na 0 0\n##end\nb 1 1\na-c\n", 6, "tunnel to unknown room 'c'"},
+		{"empty tunnel room", "1\n##start\na 0 0\n##end\nb 1 1\na-\n", 6, "invalid connection 'a-'"},
+		{"self-link", "1\n##start\na 0 0\n##end\nb 1 1\na-a\n", 6, "room 'a' links to itself"},
+		{"duplicate tunnel", "1\n##start\na 0 0\n##end\nb 1 1\na-b\nb-a\n", 7, "duplicate tunnel 'b-a'"},
+		{"start not followed by a room", "1\n##start\n##end\nb 1 1\n", 2, "##start is not followed by a room"},
+		{"start followed by a tunnel", "1\na 0 0\nb 1 1\n##start\na-b\n", 4, "##start is not followed by a room"},
+		{"start at the end", "1\n##end\nb 1 1\n##start\n", 4, "##start is not followed by a room"},
+		{"room after tunnels", "1\n##start\na 0 0\n##end\nb 1 1\na-b\nc 2 2\n", 7, "room 'c' declared after tunnels"},
+		{"unrecognized line", "1\n##start\na 0 0\nhello\n", 4, "unrecognized line 'hello'"},
+		{"missing start", "1\n##end\nb 1 1\n", 0, "start room not defined"},
+		{"missing end", "1\n##start\na 0 0\n", 0, "end room not defined"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(test.input))
+			checkParseError(t, err, test.line, test.msg)
+		})
+	}
+}
+
+// Fuzzing the parser with the examples as seeds: no input may crash it, an accepted colony has its start and end rooms
+// and only tunnels between known rooms, and small accepted colonies get solutions that keep the rules
+func FuzzParse(f *testing.F) {
+	files, _ := filepath.Glob(filepath.Join("..", "examples", "*.txt"))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(content))
+	}
+	f.Add("")
+	f.Add("\n##start\na 0 0\n##end\nb 1 1\na-b\n") // Empty first line
+	f.Add("1\n##start\na 0 0\n##end\nb 1 1\n-b\n") // Empty room name
+	f.Add("2\n##start\na 0 0\n##capacity 2\nc 1 1\n##end\nb 2 2\n##length 3\na-c\nc-b\n")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		colony, err := Parse(strings.NewReader(input))
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("got error %v, want a *ParseError", err)
+			}
+			return
+		}
+
+		if colony.NumAnts <= 0 {
+			t.Fatalf("accepted %d ants", colony.NumAnts)
+		}
+		for _, room := range []string{colony.StartRoom, colony.EndRoom} {
+			if _, ok := colony.Rooms[room]; !ok {
+				t.Fatalf("start or end room '%v' is not a room", room)
+			}
+		}
+		for room, linked := range colony.Tunnels {
+			for _, other := range append(linked, room) {
+				if _, ok := colony.Rooms[other]; !ok {
+					t.Fatalf("tunnel to unknown room '%v'", other)
+				}
+			}
+		}
+
+		// Solving is only worth it on small colonies, the fuzzer tries many inputs
+		if colony.NumAnts > 100 || len(colony.Rooms) > 50 {
+			return
+		}
+		solution, err := Solve(colony, Options{})
+		if errors.Is(err, ErrNoPath) {
+			return
+		} else if err != nil {
+			t.Fatalf("solving failed: %v", err)
+		}
+		if err := CheckSolution(colony, solution); err != nil {
+			t.Fatalf("broken solution: %v", err)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package lemin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Ant waiting in a start room for its turn to leave
+type Ant struct {
+	ID   int      // Number of the ant in the output, ants are dispatched from the start in this order
+	Path []string // Path given by the assignment
+}
+
+// Room taken by an ant on a turn
+type roomTurn struct {
+	room string
+	turn int
+}
+
+// Simulate moves the ants by minimal amount of turns on the given path combination and returns the moves of every turn.
+// Ant n takes the path given by the assignment and leaves its start room after the earlier ants of the same path.
+// Once an ant leaves the start it moves on every turn, so its whole walk is known when it leaves: the ant waits
+// in the start room until every room on its way has space on the turn it gets there and every tunnel is free
+// on the turn it enters it. A room never holds more ants than its capacity and a tunnel is entered by one ant
+// per turn. An ant entering a tunnel of length n reaches the next room n turns later, the move is recorded on arrival.
+func Simulate(colony *Colony, paths [][]string) []string {
+	movements, _ := simulate(context.Background(), colony, paths, assignAntsToPaths(colony, paths))
+	return movements
+}
+
+// Simulating the ant movements with the given assignment, checking the context once per turn.
+// Returns the context error when it is done before the moves of every turn are known.
+func simulate(ctx context.Context, colony *Colony, paths [][]string, assignment []int) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	// Initialize ants on their assigned paths and queue them at the start of every path in the order of their IDs
+	queues := make([][]Ant, len(paths))
+	for i, path := range assignment {
+		if path == -1 {
+			return nil, nil // No path leaves the start room of the ant
+		}
+		queues[path] = append(queues[path], Ant{ID: i + 1, Path: paths[path]})
+	}
+
+	occupancy := make(map[roomTurn]int)    // Ants in a room at the end of a turn
+	tunnelInUse := make(map[roomTurn]bool) // Tunnels entered on a turn, stored under "room1-room2" sorted by name
+	arrivals := make(map[int][]Move)       // Turn -> moves recorded on it
+	lastTurn := 0
+
+	// Every turn the first waiting ant of every path leaves when its walk fits in the reservations
+	for waiting, turn := colony.NumAnts, 1; waiting > 0; turn++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for path := range queues {
+			if len(queues[path]) == 0 {
+				continue
+			}
+			ant := queues[path][0]
+			walk, ok := planWalk(colony, ant.Path, turn, occupancy, tunnelInUse)
+			if !ok {
+				continue
+			}
+
+			// Reserving the rooms and the tunnels of the walk
+			for i, step := range walk {
+				from, to := ant.Path[i], ant.Path[i+1]
+				tunnelInUse[roomTurn{room: tunnelName(from, to), turn: step.turn - colony.TunnelLength(from, to) + 1}] = true
+				occupancy[step]++
+				arrivals[step.turn] = append(arrivals[step.turn], Move{Ant: ant.ID, Room: step.room})
+				lastTurn = max(lastTurn, step.turn)
+			}
+			queues[path] = queues[path][1:]
+			waiting--
+		}
+	}
+
+	// Turns where ants are only crossing long tunnels are kept empty
+	movements := make([]string, lastTurn)
+	for turn := 1; turn <= lastTurn; turn++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		moves := arrivals[turn]
+		sort.Slice(moves, func(i, j int) bool { return moves[i].Ant < moves[j].Ant })
+		turnMovements := make([]string, len(moves))
+		for i, move := range moves {
+			turnMovements[i] = fmt.Sprintf("L%d-%s", move.Ant, move.Room)
+		}
+		movements[turn-1] = strings.Join(turnMovements, " ")
+	}
+	return movements, nil
+}
+
+// Planning the walk of an ant leaving the start on the given turn: the room reached after every tunnel and the turn
+// of arrival. The ant leaves every room on the turn after arriving, false when a room is full or a tunnel is taken.
+func planWalk(colony *Colony, path []string, turn int, occupancy map[roomTurn]int, tunnelInUse map[roomTurn]bool) ([]roomTurn, bool) {
+	walk := make([]roomTurn, 0, len(path)-1)
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		if tunnelInUse[roomTurn{room: tunnelName(from, to), turn: turn}] {
+			return nil, false
+		}
+		arrival := roomTurn{room: to, turn: turn + colony.TunnelLength(from, to) - 1}
+		if occupancy[arrival] >= colony.RoomCapacity(to) {
+			return nil, false
+		}
+		walk = append(walk, arrival)
+		turn = arrival.turn + 1
+	}
+	return walk, true
+}
+
+// Name of the tunnel between two rooms, the same in both directions
+func tunnelName(room1, room2 string) string {
+	if room2 < room1 {
+		room1, room2 = room2, room1
+	}
+	return room1 + "-" + room2
+}
+
+// Assigning a path to every ant, ant n takes the path at index n-1 and -1 means no path leaves its start room.
+// Every path gets the number of ants of the optimal distribution, within it each ant takes the path
+// where it arrives first so the ants reach the end in the order of their numbers as much as possible.
+func assignAntsToPaths(colony *Colony, paths [][]string) []int {
+	quotas, _ := distributeAnts(colony, paths)
+	lengths := make([]int, len(paths))
+	for i, path := range paths {
+		lengths[i] = colony.PathLength(path)
+	}
+
+	assignment := make([]int, colony.NumAnts)
+	pathAntCounts := make([]int, len(paths))
+	for ant := range assignment {
+		start := colony.AntStart(ant + 1)
+		bestPath := -1
+		for i, path := range paths {
+			if path[0] != start || pathAntCounts[i] == quotas[i] {
+				continue
+			}
+			if bestPath == -1 || lengths[i]+pathAntCounts[i] < lengths[bestPath]+pathAntCounts[bestPath] {
+				bestPath = i
+			}
+		}
+		if bestPath != -1 {
+			pathAntCounts[bestPath]++
+		}
+		assignment[ant] = bestPath
+	}
+	return assignment
+}
@@ -0,0 +1,403 @@
+package lemin
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Returned by Solve when no path leads from the start to the end
+var ErrNoPath = errors.New("no path from start to end")
+
+// Settings for solving a colony
+type Options struct {
+	MaxPaths int // Largest number of paths to use, 0 means no limit besides the number of ants
+}
+
+// Solution of a colony
+type Solution struct {
+	Paths      [][]string   // Chosen paths from start to end
+	PathSets   [][][]string // Cheapest set of k paths for every k that was tried
+	Assignment []int        // Index of the path taken by every ant, ant n is at index n-1
+	Turns      []string     // Moves of every turn in the "L1-room L2-room" format
+	Partial    bool         // The search was stopped before trying every number of paths, the answer may be suboptimal
+}
+
+// Solve finds the paths that move all ants to the end in the fewest turns and simulates the moves
+func Solve(colony *Colony, options Options) (*Solution, error) {
+	return SolveContext(context.Background(), colony, options)
+}
+
+// SolveContext is Solve that stops when the context is cancelled or its deadline passes.
+// The path search gets half of the time left until the deadline, the other half is kept for simulating the moves.
+// When the search is stopped the best path set found until then is used and the solution is marked Partial.
+// The context error is returned when not a single path was found in time or the moves could not be simulated before the deadline.
+func SolveContext(ctx context.Context, colony *Colony, options Options) (*Solution, error) {
+	searchCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		searchCtx, cancel = context.WithDeadline(ctx, deadline.Add(-time.Until(deadline)/2))
+		defer cancel()
+	}
+
+	pathSets, err := findPathSets(searchCtx, colony, options.MaxPaths)
+	if err != nil && len(pathSets) == 0 {
+		return nil, err
+	}
+	paths := bestPathSet(colony, pathSets)
+	if paths == nil {
+		return nil, ErrNoPath
+	}
+
+	// Every ant gets a place in the assignment, nothing is allocated for them once the time is up
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	assignment := assignAntsToPaths(colony, paths)
+	turns, simulateErr := simulate(ctx, colony, paths, assignment)
+	if simulateErr != nil {
+		return nil, simulateErr
+	}
+
+	return &Solution{
+		Paths:      paths,
+		PathSets:   pathSets,
+		Assignment: assignment,
+		Turns:      turns,
+		Partial:    err != nil,
+	}, nil
+}
+
+// Edge of the flow network, every edge is stored next to its reverse edge (index ^ 1)
+type flowEdge struct {
+	to   int
+	cap  int
+	flow int
+	cost int
+}
+
+// Flow network where every room is split into an in-node (2*i) and an out-node (2*i+1).
+// Two extra nodes after the rooms join the start rooms into one source and the end rooms into one sink.
+type flowNetwork struct {
+	names  []string // room index -> room name, empty for the source and the sink
+	edges  []flowEdge
+	adj    [][]int // node -> indexes of the outgoing edges
+	source int     // out-node of the super source
+	sink   int     // in-node of the super sink
+}
+
+// Building the vertex-split flow network of the colony
+func newFlowNetwork(colony *Colony) *flowNetwork {
+	// Collecting room names in a sorted order to keep the results deterministic
+	seen := make(map[string]bool)
+	var names []string
+	for name := range colony.Rooms {
+		seen[name] = true
+		names = append(names, name)
+	}
+	for name := range colony.Tunnels {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+
+	// The super source and super sink come after the rooms
+	rooms := len(names)
+	names = append(names, "", "")
+	network := &flowNetwork{names: names, adj: make([][]int, 2*len(names)), source: 2*rooms + 1, sink: 2 * (rooms + 1)}
+
+	// The in->out edge limits how many paths can go through the room, normally only one.
+	// A start room sends at most as many paths as it has ants.
+	for i, name := range names[:rooms] {
+		if colony.IsEnd(name) {
+			continue
+		}
+		capacity := colony.RoomCapacity(name)
+		if colony.IsStart(name) {
+			capacity = colony.AntsAt(name)
+		}
+		network.addEdge(2*i, 2*i+1, capacity, 0)
+	}
+	for _, start := range colony.StartRooms() {
+		if i, ok := index[start]; ok {
+			network.addEdge(network.source, 2*i, colony.AntsAt(start), 0)
+		}
+	}
+	for _, end := range colony.EndRooms() {
+		if i, ok := index[end]; ok {
+			network.addEdge(2*i, network.sink, colony.NumAnts, 0)
+		}
+	}
+
+	// Every tunnel can be used in both directions: out(room1)->in(room2) and out(room2)->in(room1).
+	// One ant can enter a tunnel per turn and the cost is the number of turns it takes to cross.
+	for _, name := range names[:rooms] {
+		for _, linked := range colony.Tunnels[name] {
+			if colony.IsEnd(name) || colony.IsStart(linked) {
+				continue // No point in leaving the end or coming back to the start
+			}
+			network.addEdge(2*index[name]+1, 2*index[linked], 1, colony.TunnelLength(name, linked))
+		}
+	}
+
+	return network
+}
+
+// Adding an edge and its zero capacity reverse edge to the network
+func (n *flowNetwork) addEdge(from, to, capacity, cost int) {
+	n.adj[from] = append(n.adj[from], len(n.edges))
+	n.edges = append(n.edges, flowEdge{to: to, cap: capacity, cost: cost})
+	n.adj[to] = append(n.adj[to], len(n.edges))
+	n.edges = append(n.edges, flowEdge{to: from, cap: 0, cost: -cost})
+}
+
+// Priority queue item for Dijkstra
+type queueItem struct {
+	node int
+	dist int
+}
+
+type priorityQueue []queueItem
+
+func (q priorityQueue) Len() int            { return len(q) }
+func (q priorityQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q priorityQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue) Push(x interface{}) { *q = append(*q, x.(queueItem)) }
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// Finding the cheapest augmenting path with Dijkstra on reduced costs (Suurballe) and pushing one unit of flow along it.
+// Returns false when the sink cannot be reached anymore.
+func (n *flowNetwork) augment(source, sink int, potential []int) bool {
+	dist := make([]int, len(n.adj))
+	prevEdge := make([]int, len(n.adj))
+	for i := range dist {
+		dist[i] = math.MaxInt
+		prevEdge[i] = -1
+	}
+	dist[source] = 0
+
+	queue := &priorityQueue{{node: source, dist: 0}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queueItem)
+		if item.dist > dist[item.node] {
+			continue // Outdated queue entry
+		}
+		for _, e := range n.adj[item.node] {
+			edge := n.edges[e]
+			if edge.cap-edge.flow <= 0 {
+				continue
+			}
+			// Reduced cost is never negative thanks to the potentials
+			newDist := item.dist + edge.cost + potential[item.node] - potential[edge.to]
+			if newDist < dist[edge.to] {
+				dist[edge.to] = newDist
+				prevEdge[edge.to] = e
+				heap.Push(queue, queueItem{node: edge.to, dist: newDist})
+			}
+		}
+	}
+
+	if dist[sink] == math.MaxInt {
+		return false
+	}
+
+	// Updating potentials so the reduced costs stay non-negative on the next round
+	for i := range potential {
+		if dist[i] != math.MaxInt {
+			potential[i] += dist[i]
+		}
+	}
+
+	// Pushing one unit of flow from the sink back to the source
+	for node := sink; node != source; {
+		e := prevEdge[node]
+		n.edges[e].flow++
+		n.edges[e^1].flow--
+		node = n.edges[e^1].to
+	}
+	return true
+}
+
+// Decomposing the current flow into room paths from a start room to an end room
+func (n *flowNetwork) paths() [][]string {
+	source, sink := n.source, n.sink
+	remaining := make([]int, len(n.edges))
+	for i, edge := range n.edges {
+		if i%2 == 0 && edge.flow > 0 {
+			remaining[i] = edge.flow
+		}
+	}
+
+	var paths [][]string
+	for {
+		path := []string{n.names[source/2]}
+		node := source
+		for node != sink {
+			next := -1
+			for _, e := range n.adj[node] {
+				if remaining[e] > 0 {
+					next = e
+					break
+				}
+			}
+			if next == -1 {
+				break
+			}
+			remaining[next]--
+			node = n.edges[next].to
+			if node%2 == 0 { // Arriving to an in-node means entering a new room
+				path = append(path, n.names[node/2])
+			}
+		}
+		if node != sink {
+			break // No more flow leaving the source
+		}
+		paths = append(paths, path[1:len(path)-1]) // Leaving out the super source and the super sink
+	}
+	return paths
+}
+
+// Finding the cheapest set of k paths from the start rooms to the end rooms for every k. The paths are vertex-disjoint
+// unless rooms can hold more ants. The k-th set has k paths, more paths than ants are never useful.
+// When the context is done the sets found so far are returned with the context error.
+func findPathSets(ctx context.Context, colony *Colony, maxPaths int) ([][][]string, error) {
+	network := newFlowNetwork(colony)
+	potential := make([]int, len(network.adj))
+
+	if maxPaths <= 0 || maxPaths > colony.NumAnts {
+		maxPaths = colony.NumAnts
+	}
+
+	// Every augmentation adds one more disjoint path
+	var pathSets [][][]string
+	for flow := 0; flow < maxPaths; flow++ {
+		if err := ctx.Err(); err != nil {
+			return pathSets, err
+		}
+		if !network.augment(network.source, network.sink, potential) {
+			break
+		}
+		paths := network.paths()
+
+		// Sorting paths from shortest to longest
+		sort.SliceStable(paths, func(i, j int) bool {
+			return colony.PathLength(paths[i]) < colony.PathLength(paths[j])
+		})
+		pathSets = append(pathSets, paths)
+	}
+	return pathSets, nil
+}
+
+// Choosing the path set that moves all ants in the fewest turns, compared with the distribution arithmetic.
+// Start rooms the flow left without a path share the rooms of the other paths, the arithmetic takes paths as disjoint
+// so those sets are scored by simulating them. On a tie the flow's own disjoint paths win. Paths of the chosen set
+// that get no ants are dropped.
+func bestPathSet(colony *Colony, pathSets [][][]string) [][]string {
+	var bestPaths [][]string
+	bestTurns := math.MaxInt
+	bestShared := false
+	for _, flowPaths := range pathSets {
+		paths := coverStartRooms(colony, flowPaths)
+		shared := len(paths) > len(flowPaths)
+		turns := countTurns(colony, paths)
+		if shared && turns != math.MaxInt {
+			turns = len(Simulate(colony, paths))
+		}
+		if turns < bestTurns || turns == bestTurns && bestShared && !shared {
+			bestPaths = paths
+			bestTurns = turns
+			bestShared = shared
+		}
+	}
+	if bestPaths == nil {
+		return nil
+	}
+
+	counts, _ := countAntsPerPath(colony, bestPaths)
+	var used [][]string
+	for i, path := range bestPaths {
+		if counts[i] > 0 {
+			used = append(used, path)
+		}
+	}
+	return used
+}
+
+// Adding the shortest path of every start room with ants that has no path in the set. Start rooms behind the same
+// bottleneck get only one flow path between them, their ants take turns in the shared rooms during the simulation.
+func coverStartRooms(colony *Colony, paths [][]string) [][]string {
+	covered := make(map[string]bool)
+	for _, path := range paths {
+		covered[path[0]] = true
+	}
+	paths = paths[:len(paths):len(paths)] // Appending must not change the path set of the flow
+	for _, start := range colony.StartRooms() {
+		if covered[start] || colony.AntsAt(start) == 0 {
+			continue
+		}
+		if path := shortestPath(colony, start); path != nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Finding the path from the start room to the closest end room in turns (Dijkstra), nil when no end room can be reached.
+// Like in the flow network other start rooms are not entered and the path stops at the first end room.
+func shortestPath(colony *Colony, start string) []string {
+	names := []string{start}
+	index := map[string]int{start: 0}
+	dist := []int{0}
+	prev := []int{-1}
+
+	queue := &priorityQueue{{node: 0, dist: 0}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queueItem)
+		if item.dist > dist[item.node] {
+			continue // Outdated queue entry
+		}
+		room := names[item.node]
+		if colony.IsEnd(room) {
+			var path []string
+			for node := item.node; node != -1; node = prev[node] {
+				path = append([]string{names[node]}, path...)
+			}
+			return path
+		}
+
+		for _, linked := range colony.Tunnels[room] {
+			if colony.IsStart(linked) {
+				continue
+			}
+			i, seen := index[linked]
+			if !seen {
+				i = len(names)
+				index[linked] = i
+				names = append(names, linked)
+				dist = append(dist, math.MaxInt)
+				prev = append(prev, -1)
+			}
+			if newDist := item.dist + colony.TunnelLength(room, linked); newDist < dist[i] {
+				dist[i] = newDist
+				prev[i] = item.node
+				heap.Push(queue, queueItem{node: i, dist: newDist})
+			}
+		}
+	}
+	return nil
+}
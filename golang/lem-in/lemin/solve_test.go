@@ -0,0 +1,80 @@
+package lemin
+
+import (
+	"strings"
+	"testing"
+)
+
+// Every solution keeps the move rules, takes at most ants + shortest path - 1 turns and never beats the lower bound
+func TestSolutionProperties(t *testing.T) {
+	for seed := int64(1); seed <= 300; seed++ {
+		colony, err := RandomColony(seed)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		solution, err := Solve(colony, Options{})
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if err := CheckSolution(colony, solution); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if bound := ComputeStats(colony, solution).LowerBound; len(solution.Turns) < bound {
+			t.Fatalf("seed %d: %d turns is below the lower bound %d", seed, len(solution.Turns), bound)
+		}
+		if len(solution.Assignment) != colony.NumAnts {
+			t.Fatalf("seed %d: %d ants assigned instead of %d", seed, len(solution.Assignment), colony.NumAnts)
+		}
+	}
+}
+
+func TestMultiStartSolutions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		turns int
+		bound int
+	}{
+		{
+			name:  "shared bottleneck",
+			input: "3\n##start 2\ns1 0 0\n##start 1\ns2 0 2\na 1 1\n##end\ne 2 1\ns1-a\ns2-a\na-e\n",
+			turns: 4,
+			bound: 4,
+		},
+		{
+			name:  "separate start rooms",
+			input: "6\n##start 4\ns1 0 0\n##start 2\ns2 0 2\na 1 0\nb 1 2\n##end\ne1 2 0\n##end\ne2 2 2\ns1-a\na-e1\ns2-b\nb-e2\n",
+			turns: 5,
+			bound: 5,
+		},
+		{
+			// Covering s2 with s2-b-e2 ties with the disjoint flow paths in the arithmetic, but the ants wait in b
+			name:  "disjoint paths over a shared room",
+			input: "5\n##start 3\ns1 0 0\n##start 2\ns2 0 4\na 1 0\nb 1 2\nc 1 4\n##end\ne1 2 0\n##end\ne2 2 4\ns1-a\ns1-b\ns2-b\ns2-c\na-e1\nb-e1\nc-e2\nb-e2\n",
+			turns: 3,
+			bound: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			colony, err := ParseWithOptions(strings.NewReader(test.input), ParseOptions{Multi: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			solution, err := Solve(colony, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckSolution(colony, solution); err != nil {
+				t.Fatal(err)
+			}
+			if len(solution.Turns) != test.turns {
+				t.Errorf("got %d turns, want %d", len(solution.Turns), test.turns)
+			}
+			if bound := ComputeStats(colony, solution).LowerBound; bound != test.bound {
+				t.Errorf("got lower bound %d, want %d", bound, test.bound)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package lemin
+
+import "context"
+
+// Summary of a solution and how far it is from the best possible one
+type Stats struct {
+	DisjointPaths int        `json:"disjointPaths"`        // Maximum number of vertex-disjoint paths found (limited by the number of ants)
+	Paths         [][]string `json:"-"`                    // Chosen paths
+	PathLengths   []int      `json:"pathLengths"`          // Turns needed to walk each chosen path
+	AntsPerPath   []int      `json:"antsPerPath"`          // Number of ants sent on each chosen path
+	Turns         int        `json:"turns"`                // Achieved number of turns
+	LowerBound    int        `json:"lowerBound"`           // Theoretical minimum number of turns, 0 when the search was stopped early
+	Suboptimal    bool       `json:"suboptimal,omitempty"` // The search was stopped early, a better answer may exist
+}
+
+// ComputeStats collects the statistics of the solution
+func ComputeStats(colony *Colony, solution *Solution) *Stats {
+	stats := &Stats{
+		DisjointPaths: len(solution.PathSets),
+		Paths:         solution.Paths,
+		Turns:         len(solution.Turns),
+		Suboptimal:    solution.Partial,
+	}
+	// Path sets that were never tried could lower the bound, so it is only known after a full search
+	if !solution.Partial {
+		stats.LowerBound = turnsLowerBound(colony, solution.PathSets)
+	}
+	if !solution.Partial && len(colony.StartRooms()) > 1 {
+		stats.LowerBound = max(stats.LowerBound, startRoomsLowerBound(colony))
+	}
+	stats.AntsPerPath, _ = countAntsPerPath(colony, solution.Paths)
+	for _, path := range solution.Paths {
+		stats.PathLengths = append(stats.PathLengths, colony.PathLength(path))
+	}
+	return stats
+}
+
+// Calculating the minimum number of turns any set of disjoint paths can reach with turnsOnPaths.
+// The cheapest k paths give the lowest bound for each k.
+func turnsLowerBound(colony *Colony, pathSets [][][]string) int {
+	bound := 0
+	for _, paths := range pathSets {
+		k, total := len(paths), 0
+		for _, path := range paths {
+			total += colony.PathLength(path)
+		}
+		turns := turnsOnPaths(colony.NumAnts, total, k)
+		// No solution is faster than walking the shortest path once
+		if shortest := colony.PathLength(paths[0]); turns < shortest {
+			turns = shortest
+		}
+		if bound == 0 || turns < bound {
+			bound = turns
+		}
+	}
+	return bound
+}
+
+// With several start rooms the bound of turnsLowerBound lets every ant use the paths of any start room.
+// The ants of every start room also need at least the turns they would take if their start room was the only one,
+// the slowest start room gives the bound.
+func startRoomsLowerBound(colony *Colony) int {
+	bound := 0
+	for _, start := range colony.StartRooms() {
+		ants := colony.AntsAt(start)
+		if ants == 0 {
+			continue
+		}
+		alone := *colony
+		alone.NumAnts, alone.StartRoom, alone.Starts = ants, start, []string{start}
+		alone.StartAnts = map[string]int{start: ants}
+		pathSets, _ := findPathSets(context.Background(), &alone, 0)
+		bound = max(bound, turnsLowerBound(&alone, pathSets))
+	}
+	return bound
+}
@@ -0,0 +1,274 @@
+package lemin
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Rule violation found while replaying the moves
+type MoveError struct {
+	Turn int
+	Ant  int // 0 when the violation is not caused by a single ant
+	Msg  string
+}
+
+func (e *MoveError) Error() string {
+	if e.Ant == 0 {
+		return fmt.Sprintf("turn %d: %s", e.Turn, e.Msg)
+	}
+	return fmt.Sprintf("turn %d, ant %d: %s", e.Turn, e.Ant, e.Msg)
+}
+
+// FormatTurn gives the move log line of a turn, "Turn N: L1-x L2-y". A turn without moves, when every ant on its way
+// is inside a long tunnel, is "Turn N:" without a trailing space. ReadMoves reads both back.
+func FormatTurn(number int, moves string) string {
+	if moves == "" {
+		return fmt.Sprintf("Turn %d:", number)
+	}
+	return fmt.Sprintf("Turn %d: %s", number, moves)
+}
+
+// ReadMoves reads the turns from a move log, other lines (like the printed colony) are skipped.
+// Accepts both "Turn N: L1-x L2-y" lines and plain "L1-x L2-y" lines.
+func ReadMoves(r io.Reader) ([]string, error) {
+	var turns []string
+	scanner := NewLineScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "Turn ") {
+			number, moves, found := strings.Cut(strings.TrimPrefix(line, "Turn "), ":")
+			turn, err := strconv.Atoi(number)
+			if !found || err != nil {
+				return nil, fmt.Errorf("invalid turn line '%v'", line)
+			}
+			if turn != len(turns)+1 {
+				return nil, fmt.Errorf("turn %d found where turn %d was expected", turn, len(turns)+1)
+			}
+			turns = append(turns, strings.TrimSpace(moves))
+		} else if strings.HasPrefix(line, "L") { // Room names cannot start with L so this is always a move line
+			turns = append(turns, line)
+		}
+	}
+	return turns, scanner.Err()
+}
+
+// Verify replays the turns and checks every movement rule, the first violation is returned as a *MoveError.
+// A move is printed when the ant arrives, so an ant crossing a tunnel of length n entered it n-1 turns earlier.
+func Verify(colony *Colony, turns []string) error {
+	// Tunnels in both directions for quick lookup
+	tunnels := make(map[string]bool)
+	for room, linkedRooms := range colony.Tunnels {
+		for _, linked := range linkedRooms {
+			tunnels[room+"-"+linked] = true
+		}
+	}
+
+	// Splitting the turns into moves, a broken move ends the replay on its turn
+	var parsed [][]Move
+	var parseErr error
+	for i, turn := range turns {
+		moves := []Move{}
+		for _, move := range strings.Fields(turn) {
+			ant, room, err := splitMove(move)
+			if err != nil {
+				parseErr = &MoveError{Turn: i + 1, Msg: err.Error()}
+				break
+			}
+			if ant < 1 || ant > colony.NumAnts {
+				parseErr = &MoveError{Turn: i + 1, Ant: ant, Msg: fmt.Sprintf("there is no ant %d", ant)}
+				break
+			}
+			moves = append(moves, Move{Ant: ant, Room: room})
+		}
+		if parseErr != nil {
+			break
+		}
+		parsed = append(parsed, moves)
+	}
+
+	// Arrival turns of every ant, to know when it has to leave its room
+	type arrival struct {
+		turn int
+		room string
+	}
+	arrivals := make([][]arrival, colony.NumAnts+1) // Ant number -> arrivals, index 0 is unused
+	for i, moves := range parsed {
+		for _, move := range moves {
+			arrivals[move.Ant] = append(arrivals[move.Ant], arrival{turn: i + 1, room: move.Room})
+		}
+	}
+
+	// Every ant starts from its start room
+	position := make([]string, colony.NumAnts+1)
+	arrivedOn := make([]int, colony.NumAnts+1) // Turn of the last arrival
+	nextArrival := make([]int, colony.NumAnts+1)
+	for ant := 1; ant <= colony.NumAnts; ant++ {
+		position[ant] = colony.AntStart(ant)
+	}
+	occupants := make(map[string][]int) // Room -> ants in it, start and end are not tracked
+	departures := make(map[int][]int)   // Turn -> ants entering a tunnel on that turn
+	inTransit := 0
+
+	// Finding out when the ant leaves its room for the next arrival
+	scheduleDeparture := func(ant int) {
+		if nextArrival[ant] < len(arrivals[ant]) {
+			next := arrivals[ant][nextArrival[ant]]
+			if tunnels[position[ant]+"-"+next.room] {
+				turn := next.turn - colony.TunnelLength(position[ant], next.room) + 1
+				departures[turn] = append(departures[turn], ant)
+			}
+		}
+	}
+	for ant := 1; ant <= colony.NumAnts; ant++ {
+		scheduleDeparture(ant)
+	}
+
+	for i, moves := range parsed {
+		turnNum := i + 1
+		tunnelUsed := make(map[string]bool)
+
+		// Ants entering tunnels leave their rooms first
+		for _, ant := range departures[turnNum] {
+			from, to := position[ant], arrivals[ant][nextArrival[ant]].room
+			tunnel := from + "-" + to
+			if to < from {
+				tunnel = to + "-" + from
+			}
+			if tunnelUsed[tunnel] {
+				return &MoveError{Turn: turnNum, Ant: ant, Msg: fmt.Sprintf("tunnel '%v' already used on this turn", tunnel)}
+			}
+			tunnelUsed[tunnel] = true
+			occupants[from] = removeAnt(occupants[from], ant)
+			inTransit++
+		}
+
+		if len(moves) == 0 && inTransit == 0 {
+			return &MoveError{Turn: turnNum, Msg: "no ant moved"}
+		}
+
+		moved := make(map[int]bool)
+		var entered []string // Entered rooms in the order of the moves
+		for _, move := range moves {
+			ant, room := move.Ant, move.Room
+
+			// Checking the ant
+			if moved[ant] {
+				return &MoveError{Turn: turnNum, Ant: ant, Msg: "moved twice on the same turn"}
+			}
+			moved[ant] = true
+			from := position[ant]
+			if colony.IsEnd(from) {
+				return &MoveError{Turn: turnNum, Ant: ant, Msg: "moved after reaching the end"}
+			}
+
+			// Checking the tunnel
+			if _, exists := colony.Rooms[room]; !exists {
+				return &MoveError{Turn: turnNum, Ant: ant, Msg: fmt.Sprintf("unknown room '%v'", room)}
+			}
+			if !tunnels[from+"-"+room] {
+				return &MoveError{Turn: turnNum, Ant: ant, Msg: fmt.Sprintf("no tunnel from '%v' to '%v'", from, room)}
+			}
+			if length := colony.TunnelLength(from, room); turnNum-length+1 <= arrivedOn[ant] {
+				return &MoveError{Turn: turnNum, Ant: ant, Msg: fmt.Sprintf("reached '%v' too early, the tunnel from '%v' takes %d turns", room, from, length)}
+			}
+
+			inTransit--
+			position[ant] = room
+			arrivedOn[ant] = turnNum
+			nextArrival[ant]++
+			if !colony.IsStart(room) && !colony.IsEnd(room) {
+				occupants[room] = append(occupants[room], ant)
+				entered = append(entered, room)
+			}
+			scheduleDeparture(ant)
+		}
+
+		// Start and end can hold any number of ants, other rooms only as many as their capacity
+		for _, room := range entered {
+			ants := occupants[room]
+			if capacity := colony.RoomCapacity(room); len(ants) > capacity {
+				last := ants[len(ants)-1]
+				if capacity == 1 {
+					return &MoveError{Turn: turnNum, Ant: last, Msg: fmt.Sprintf("room '%v' is occupied by ant %d", room, ants[0])}
+				}
+				return &MoveError{Turn: turnNum, Ant: last, Msg: fmt.Sprintf("room '%v' can hold only %d ants", room, capacity)}
+			}
+		}
+	}
+
+	if parseErr != nil {
+		return parseErr
+	}
+
+	// Every ant has to be at the end when the moves are over
+	for ant := 1; ant <= colony.NumAnts; ant++ {
+		if !colony.IsEnd(position[ant]) {
+			return &MoveError{Turn: len(turns), Ant: ant, Msg: fmt.Sprintf("did not reach the end, stopped in '%v'", position[ant])}
+		}
+	}
+	return nil
+}
+
+// CheckSolution checks the properties every solution has to keep: the moves follow the rules of Verify and
+// they take at most as many turns as sending the ants one by one on the shortest path (ants + shortest length - 1).
+// The turn limit is only checked with a single start room, other start rooms can have longer shortest paths.
+func CheckSolution(colony *Colony, solution *Solution) error {
+	if err := Verify(colony, solution.Turns); err != nil {
+		return err
+	}
+	if len(solution.PathSets) == 0 || len(colony.StartRooms()) > 1 {
+		return nil
+	}
+	// The first path of the flow is the shortest one
+	bound := colony.NumAnts + colony.PathLength(solution.PathSets[0][0]) - 1
+	if len(solution.Turns) > bound {
+		return fmt.Errorf("%d turns is more than the %d of sending the ants one by one on the shortest path", len(solution.Turns), bound)
+	}
+	return nil
+}
+
+// Removing an ant from the list of ants in a room
+func removeAnt(ants []int, ant int) []int {
+	for i, a := range ants {
+		if a == ant {
+			return append(ants[:i], ants[i+1:]...)
+		}
+	}
+	return ants
+}
+
+// Splitting a move "Lx-y" into the ant number x and the room y
+func splitMove(move string) (int, string, error) {
+	antPart, room, found := strings.Cut(move, "-")
+	ant, err := strconv.Atoi(strings.TrimPrefix(antPart, "L"))
+	if !found || !strings.HasPrefix(antPart, "L") || err != nil || room == "" {
+		return 0, "", fmt.Errorf("invalid move '%v'", move)
+	}
+	return ant, room, nil
+}
+
+// Single move of a turn
+type Move struct {
+	Ant  int    `json:"ant"`
+	Room string `json:"room"`
+}
+
+// SplitTurns splits every turn of a solution into single moves
+func SplitTurns(solution []string) ([][]Move, error) {
+	turns := make([][]Move, 0, len(solution))
+	for _, turn := range solution {
+		moves := []Move{}
+		for _, move := range strings.Fields(turn) {
+			ant, room, err := splitMove(move)
+			if err != nil {
+				return nil, err
+			}
+			moves = append(moves, Move{Ant: ant, Room: room})
+		}
+		turns = append(turns, moves)
+	}
+	return turns, nil
+}
@@ -1,56 +1,136 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"sort"
+	"path/filepath"
+
+	"lemin/lemin"
 )
 
 func main() {
 
-	if len(os.Args) != 2 {
-		Exit(fmt.Sprintf("Usage: 'go run . [filename]'"))
+	// Running the subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "generate":
+			runGenerate(os.Args[2:])
+			return
+		case "verify":
+			runVerify(os.Args[2:])
+			return
+		case "analyze":
+			runAnalyze(os.Args[2:])
+			return
+		case "batch":
+			runBatch(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		}
 	}
 
-	// Adding file contents to a global variable
-	content, err := fileContents(os.Args[1])
+	stats := flag.Bool("stats", false, "print path and turn statistics after the result")
+	render := flag.String("render", "", "write an HTML animation of the simulation to the given file")
+	dot := flag.String("dot", "", "write the colony and the chosen paths as a Graphviz DOT graph to the given file ('-' for standard output)")
+	tuiMode := flag.Bool("tui", false, "step through the simulation in an interactive terminal view")
+	format := flag.String("format", "text", "output format: text or json")
+	multi := flag.Bool("multi", false, "allow several ##start and ##end rooms, \"##start N\" puts N ants in a start room")
+	timeout := flag.Duration("timeout", 0, "stop searching after the given time (e.g. 5s) and print the best answer found so far")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		Exit(fmt.Sprintf("Usage: 'go run . [flags] [filename]' (use '-' to read from standard input)"))
+	}
+	if *format != "text" && *format != "json" {
+		Exit(fmt.Sprintf("Unknown output format '%v', use text or json", *format))
+	}
+
+	// Opening the colony file or the standard input
+	source, err := openColony(flag.Arg(0))
 	if err != nil {
 		Exit(fmt.Sprint("Error reading the file contents: ", err))
 	}
+	defer source.Close()
 
-	// Parsing file contents into ParsedData struct
-	data, err := parseInput(content)
+	// Parsing the colony while reading it
+	colony, err := lemin.ParseWithOptions(source.Reader(), lemin.ParseOptions{Multi: *multi})
 	if err != nil {
 		Exit(fmt.Sprint("ERROR: invalid data format: ", err))
 	}
 
-	// Find all paths from StartRoom to EndRoom
-	paths := findPaths(data.Tunnels, data.StartRoom, data.EndRoom)
+	// Limiting the search time when asked, the best answer found by then is used
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
 
-	// Variable to hold all non-crossing combinations
-	var allCombinations [][][]string
+	// Finding the best set of non-crossing paths from the start rooms to the end rooms and simulating the ant movements
+	solution, err := lemin.SolveContext(ctx, colony, lemin.Options{})
+	if errors.Is(err, lemin.ErrNoPath) {
+		Exit(fmt.Sprint("ERROR: invalid data format: no valid combinations"))
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		Exit(fmt.Sprint("ERROR: no solution found within ", *timeout))
+	} else if err != nil {
+		Exit(fmt.Sprint("ERROR: ", err))
+	}
 
-	// Recursively finding all non-crossing combinations
-	findNonCrossingCombinations(paths, [][]string{}, 0, &allCombinations)
+	// Writing the animation of the ant movements
+	if *render != "" {
+		if err := renderHTML(*render, filepath.Base(flag.Arg(0)), colony, solution.Turns); err != nil {
+			Exit(fmt.Sprint("Error writing the animation: ", err))
+		}
+	}
 
-	if allCombinations == nil {
-		Exit(fmt.Sprint("ERROR: invalid data format: no valid combinations"))
+	// Writing the graph for Graphviz, on the standard output it replaces the result
+	if *dot != "" {
+		if err := writeDOT(*dot, colony, solution); err != nil {
+			Exit(fmt.Sprint("Error writing the graph: ", err))
+		}
+		if *dot == "-" {
+			return
+		}
 	}
 
-	var allSolutions [][]string
+	// Showing the simulation in the terminal instead of printing it
+	if *tuiMode {
+		if err := runTUI(colony, solution.Turns); err != nil {
+			Exit(fmt.Sprint("Error starting the terminal view: ", err))
+		}
+		return
+	}
 
-	// Print all combinations
-	for _, combination := range allCombinations {
-		movements := simulateAntMovement(combination, data.NumAnts, data.StartRoom, data.EndRoom)
-		allSolutions = append(allSolutions, movements)
+	var solutionStats *lemin.Stats
+	if *stats {
+		solutionStats = lemin.ComputeStats(colony, solution)
 	}
 
-	// Sorthing solutions from shortest to longest
-	sort.Slice(allSolutions, func(i, j int) bool {
-		return len(allSolutions[i]) < len(allSolutions[j])
-	})
+	if *format == "json" {
+		if err := PrintJSON(os.Stdout, colony, solution, solutionStats); err != nil {
+			Exit(fmt.Sprint("Error writing the result: ", err))
+		}
+		return
+	}
 
-	PrintResult(content, allSolutions[0])
+	// Reading the file contents again for printing
+	content, err := source.Content()
+	if err != nil {
+		Exit(fmt.Sprint("Error reading the file contents: ", err))
+	}
+
+	PrintResult(content, solution.Turns)
+
+	if solutionStats != nil {
+		PrintStats(os.Stdout, solutionStats)
+	}
 }
 
 // Print exit message and exit program
@@ -60,16 +140,19 @@ func Exit(msg string) {
 }
 
 // Print file contents and the turns on the shortest path combination
-func PrintResult(content, solution []string) {
-	// Printing file contents
-	for _, line := range content {
-		fmt.Println(line)
+func PrintResult(content io.Reader, solution []string) {
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	// Printing file contents line by line
+	lines := lemin.NewLineScanner(content)
+	for lines.Scan() {
+		fmt.Fprintln(out, lines.Text())
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 
 	// Print the turns
 	for i, turn := range solution {
-
-		fmt.Printf("Turn %d: %v\n", i+1, turn)
+		fmt.Fprintln(out, lemin.FormatTurn(i+1, turn))
 	}
 }
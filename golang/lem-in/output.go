@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"lemin/lemin"
+)
+
+// Solution in the structure of the JSON output
+type jsonOutput struct {
+	Ants       int              `json:"ants"`
+	Start      string           `json:"start"`
+	End        string           `json:"end"`
+	Starts     map[string]int   `json:"starts,omitempty"` // Ants of every start room when there are several
+	Ends       []string         `json:"ends,omitempty"`
+	Rooms      []jsonRoom       `json:"rooms"`
+	Tunnels    [][2]string      `json:"tunnels"`
+	Lengths    map[string]int   `json:"lengths,omitempty"`
+	Paths      [][]string       `json:"paths"`
+	Assignment []jsonAssignment `json:"assignment"`
+	Turns      [][]lemin.Move   `json:"turns"`
+	Stats      *lemin.Stats     `json:"stats,omitempty"`
+}
+
+type jsonRoom struct {
+	Name     string `json:"name"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Capacity int    `json:"capacity,omitempty"`
+}
+
+// Path taken by an ant, the path is an index of the paths list
+type jsonAssignment struct {
+	Ant  int `json:"ant"`
+	Path int `json:"path"`
+}
+
+// Writing the colony, the chosen paths and the turns as JSON
+func PrintJSON(w io.Writer, data *lemin.Colony, solution *lemin.Solution, stats *lemin.Stats) error {
+	paths := solution.Paths
+	output := jsonOutput{
+		Ants:       data.NumAnts,
+		Start:      data.StartRoom,
+		End:        data.EndRoom,
+		Rooms:      []jsonRoom{},
+		Tunnels:    [][2]string{},
+		Paths:      paths,
+		Assignment: []jsonAssignment{},
+		Stats:      stats,
+	}
+
+	if len(data.StartRooms()) > 1 || len(data.EndRooms()) > 1 {
+		output.Starts = make(map[string]int)
+		for _, start := range data.StartRooms() {
+			output.Starts[start] = data.AntsAt(start)
+		}
+		output.Ends = data.EndRooms()
+	}
+
+	// Rooms and tunnels in a stable order
+	var names []string
+	for name := range data.Rooms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		room := data.Rooms[name]
+		output.Rooms = append(output.Rooms, jsonRoom{Name: room.Name, X: room.X, Y: room.Y, Capacity: room.Capacity})
+		for _, linked := range data.Tunnels[name] {
+			if name < linked {
+				output.Tunnels = append(output.Tunnels, [2]string{name, linked})
+				if length := data.TunnelLength(name, linked); length > 1 {
+					if output.Lengths == nil {
+						output.Lengths = make(map[string]int)
+					}
+					output.Lengths[name+"-"+linked] = length
+				}
+			}
+		}
+	}
+
+	turns, err := lemin.SplitTurns(solution.Turns)
+	if err != nil {
+		return err
+	}
+	output.Turns = turns
+
+	// Ant numbers of the moves are the ones of the assignment
+	for i, path := range solution.Assignment {
+		output.Assignment = append(output.Assignment, jsonAssignment{Ant: i + 1, Path: path})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"html/template"
+	"os"
+	"sort"
+
+	"lemin/lemin"
+)
+
+// Colony and turns in the form the animation script uses
+type renderData struct {
+	Title     string
+	Starts    []string
+	Ends      []string
+	AntStarts []string // Start room of every ant, index 0 is unused
+	NumAnts   int
+	Rooms     []lemin.Room
+	Tunnels   [][2]string
+	Turns     [][]lemin.Move
+}
+
+// Writing the colony and the ant movements as a self-contained HTML animation
+func renderHTML(fileName, title string, data *lemin.Colony, solution []string) error {
+	page := renderData{
+		Title:     title,
+		Starts:    data.StartRooms(),
+		Ends:      data.EndRooms(),
+		AntStarts: []string{""},
+		NumAnts:   data.NumAnts,
+	}
+
+	for ant := 1; ant <= data.NumAnts; ant++ {
+		page.AntStarts = append(page.AntStarts, data.AntStart(ant))
+	}
+
+	// Rooms and tunnels in a stable order
+	for _, room := range data.Rooms {
+		page.Rooms = append(page.Rooms, room)
+	}
+	sort.Slice(page.Rooms, func(i, j int) bool {
+		return page.Rooms[i].Name < page.Rooms[j].Name
+	})
+	for _, room := range page.Rooms {
+		for _, linked := range data.Tunnels[room.Name] {
+			if room.Name < linked {
+				page.Tunnels = append(page.Tunnels, [2]string{room.Name, linked})
+			}
+		}
+	}
+
+	// Splitting every turn into single moves
+	turns, err := lemin.SplitTurns(solution)
+	if err != nil {
+		return err
+	}
+	page.Turns = turns
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return renderTemplate.Execute(file, page)
+}
+
+// The page draws the colony with SVG and moves the ants with plain JavaScript, no external assets are needed
+var renderTemplate = template.Must(template.New("render").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>lem-in: {{.Title}}</title>
+<style>
+  body { margin: 0; font-family: sans-serif; background: #1e1e24; color: #eee; }
+  header { display: flex; gap: 12px; align-items: center; padding: 10px 16px; background: #2b2b33; }
+  button { background: #444; color: #eee; border: 1px solid #666; border-radius: 4px; padding: 4px 10px; cursor: pointer; }
+  button:hover { background: #555; }
+  #turn { min-width: 110px; }
+  svg { display: block; width: 100vw; height: calc(100vh - 48px); }
+  .tunnel { stroke: #666; stroke-width: 2; }
+  .room { fill: #3a3a44; stroke: #999; stroke-width: 2; }
+  .room.start { fill: #2e7d32; }
+  .room.end { fill: #c62828; }
+  .label { fill: #ddd; font-size: 12px; text-anchor: middle; pointer-events: none; }
+  .ant { fill: #ffb300; stroke: #000; transition: transform 0.45s ease-in-out, opacity 0.45s; }
+  .ant text { fill: #000; font-size: 9px; text-anchor: middle; dominant-baseline: central; stroke: none; }
+</style>
+</head>
+<body>
+<header>
+  <strong>{{.Title}}</strong>
+  <button id="first">&#124;&lt;</button>
+  <button id="prev">&lt;</button>
+  <button id="play">Play</button>
+  <button id="next">&gt;</button>
+  <button id="last">&gt;&#124;</button>
+  <span id="turn"></span>
+  <span id="moves"></span>
+</header>
+<svg id="colony" xmlns="http://www.w3.org/2000/svg"></svg>
+<script>
+const colony = {
+  starts: {{.Starts}},
+  ends: {{.Ends}},
+  antStarts: {{.AntStarts}},
+  ants: {{.NumAnts}},
+  rooms: {{.Rooms}},
+  tunnels: {{.Tunnels}},
+  turns: {{.Turns}}
+};
+
+const svgNS = "http://www.w3.org/2000/svg";
+const svg = document.getElementById("colony");
+const cell = 60, margin = 40, radius = 14;
+
+// Scaling room coordinates to the drawing
+const xs = colony.rooms.map(r => r.X), ys = colony.rooms.map(r => r.Y);
+const minX = Math.min(...xs), minY = Math.min(...ys);
+const width = (Math.max(...xs) - minX) * cell + 2 * margin;
+const height = (Math.max(...ys) - minY) * cell + 2 * margin;
+svg.setAttribute("viewBox", "0 0 " + width + " " + height);
+
+const position = {};
+for (const room of colony.rooms) {
+  position[room.Name] = [(room.X - minX) * cell + margin, (room.Y - minY) * cell + margin];
+}
+
+function element(name, attributes, parent) {
+  const el = document.createElementNS(svgNS, name);
+  for (const key in attributes) el.setAttribute(key, attributes[key]);
+  parent.appendChild(el);
+  return el;
+}
+
+for (const [a, b] of colony.tunnels) {
+  element("line", {class: "tunnel", x1: position[a][0], y1: position[a][1], x2: position[b][0], y2: position[b][1]}, svg);
+}
+const labels = {};
+for (const room of colony.rooms) {
+  let kind = colony.starts.includes(room.Name) ? " start" : colony.ends.includes(room.Name) ? " end" : "";
+  element("circle", {class: "room" + kind, cx: position[room.Name][0], cy: position[room.Name][1], r: radius}, svg);
+  labels[room.Name] = element("text", {class: "label", x: position[room.Name][0], y: position[room.Name][1] - radius - 4}, svg);
+  labels[room.Name].textContent = room.Name;
+}
+
+// Room of every ant after every turn, index 0 is the situation before the first turn
+const states = [colony.antStarts];
+for (const turn of colony.turns) {
+  const state = states[states.length - 1].slice();
+  for (const move of turn) state[move.ant] = move.room;
+  states.push(state);
+}
+
+const tokens = [null];
+for (let ant = 1; ant <= colony.ants; ant++) {
+  const token = element("g", {class: "ant"}, svg);
+  element("circle", {r: 8}, token);
+  element("text", {}, token).textContent = ant;
+  tokens.push(token);
+}
+
+let current = 0, timer = null;
+
+function show(turn) {
+  current = Math.max(0, Math.min(turn, states.length - 1));
+  const state = states[current];
+  const terminals = colony.starts.concat(colony.ends);
+  const count = {};
+  for (const room of terminals) count[room] = 0;
+  for (let ant = 1; ant <= colony.ants; ant++) {
+    const [x, y] = position[state[ant]];
+    const token = tokens[ant];
+    token.style.transform = "translate(" + x + "px, " + y + "px)";
+    if (state[ant] in count) count[state[ant]]++;
+    // Only the ants on the way are shown, start and end rooms show their counts instead
+    token.style.opacity = state[ant] in count ? 0 : 1;
+  }
+  for (const room of terminals) labels[room].textContent = room + " (" + count[room] + ")";
+  document.getElementById("turn").textContent = "Turn " + current + " / " + (states.length - 1);
+  document.getElementById("moves").textContent = current > 0 ?
+    colony.turns[current - 1].map(m => "L" + m.ant + "-" + m.room).join(" ") : "";
+}
+
+function pause() {
+  clearInterval(timer);
+  timer = null;
+  document.getElementById("play").textContent = "Play";
+}
+
+document.getElementById("first").onclick = () => { pause(); show(0); };
+document.getElementById("prev").onclick = () => { pause(); show(current - 1); };
+document.getElementById("next").onclick = () => { pause(); show(current + 1); };
+document.getElementById("last").onclick = () => { pause(); show(states.length - 1); };
+document.getElementById("play").onclick = () => {
+  if (timer) return pause();
+  if (current === states.length - 1) show(0);
+  document.getElementById("play").textContent = "Pause";
+  timer = setInterval(() => {
+    if (current === states.length - 1) return pause();
+    show(current + 1);
+  }, 700);
+};
+document.addEventListener("keydown", e => {
+  if (e.key === "ArrowRight") document.getElementById("next").click();
+  if (e.key === "ArrowLeft") document.getElementById("prev").click();
+  if (e.key === " ") { e.preventDefault(); document.getElementById("play").click(); }
+});
+
+show(0);
+</script>
+</body>
+</html>
+`))
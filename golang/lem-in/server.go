@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"lemin/lemin"
+)
+
+// Settings of the solving service
+type server struct {
+	maxBytes int64         // Largest accepted request body
+	maxAnts  int           // Largest accepted number of ants, the simulation keeps every ant in memory
+	timeout  time.Duration // Longest time a single solve may take
+}
+
+// Body of a verify request, both fields hold the text of a file
+type verifyRequest struct {
+	Colony string `json:"colony"`
+	Moves  string `json:"moves"`
+}
+
+// Answer of a verify request, the turn and ant are set when a move breaks a rule
+type verifyResponse struct {
+	Valid bool   `json:"valid"`
+	Turns int    `json:"turns,omitempty"`
+	Error string `json:"error,omitempty"`
+	Turn  int    `json:"turn,omitempty"`
+	Ant   int    `json:"ant,omitempty"`
+}
+
+// Running the serve subcommand: solving colonies over HTTP
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	maxBytes := flags.Int64("max-bytes", 1<<20, "largest accepted request body in bytes")
+	maxAnts := flags.Int("max-ants", 100000, "largest accepted number of ants in a colony")
+	timeout := flags.Duration("timeout", 10*time.Second, "longest time a single solve may take")
+	flags.Parse(args)
+
+	if *maxBytes <= 0 || *maxAnts <= 0 || *timeout <= 0 {
+		Exit("The request size limit, the ant limit and the timeout must be positive")
+	}
+
+	s := &server{maxBytes: *maxBytes, maxAnts: *maxAnts, timeout: *timeout}
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("Listening on %s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		Exit(fmt.Sprint("Error running the server: ", err))
+	}
+}
+
+// Registering the endpoints of the service
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /solve", s.handleSolve)
+	mux.HandleFunc("POST /verify", s.handleVerify)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
+
+// Solving the colony in the request body, the answer is the moves and the statistics as text or JSON
+func (s *server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	wantJSON := r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json")
+
+	// Reading the whole body before parsing, a body cut at the limit would otherwise fail on its truncated last line
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBytes))
+	if status := parseStatus(err); status == http.StatusRequestEntityTooLarge {
+		s.writeError(w, wantJSON, status, fmt.Sprintf("request body over %d bytes", s.maxBytes))
+		return
+	} else if err != nil {
+		s.writeError(w, wantJSON, status, fmt.Sprint("invalid request: ", err))
+		return
+	}
+	colony, err := lemin.Parse(bytes.NewReader(data))
+	if err != nil {
+		s.writeError(w, wantJSON, http.StatusBadRequest, fmt.Sprint("invalid data format: ", err))
+		return
+	}
+	if colony.NumAnts > s.maxAnts {
+		s.writeError(w, wantJSON, http.StatusUnprocessableEntity, s.tooManyAnts(colony))
+		return
+	}
+
+	// Limiting the search time, the best answer found by then is sent and the stats tell it may be suboptimal
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	solution, err := lemin.SolveContext(ctx, colony, lemin.Options{})
+	if errors.Is(err, lemin.ErrNoPath) {
+		s.writeError(w, wantJSON, http.StatusUnprocessableEntity, "invalid data format: no valid combinations")
+		return
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		s.writeError(w, wantJSON, http.StatusGatewayTimeout, "no solution found before the solve timeout")
+		return
+	} else if err != nil {
+		s.writeError(w, wantJSON, http.StatusInternalServerError, err.Error())
+		return
+	}
+	stats := lemin.ComputeStats(colony, solution)
+
+	// Building the whole answer first so an error can still change the status code
+	var body bytes.Buffer
+	if wantJSON {
+		if err := PrintJSON(&body, colony, solution, stats); err != nil {
+			s.writeError(w, wantJSON, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		for i, turn := range solution.Turns {
+			fmt.Fprintln(&body, lemin.FormatTurn(i+1, turn))
+		}
+		PrintStats(&body, stats)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	body.WriteTo(w)
+}
+
+// Checking a move log against a colony, both given in a JSON body
+func (s *server) handleVerify(w http.ResponseWriter, r *http.Request) {
+	var request verifyRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBytes))
+	if err := decoder.Decode(&request); err != nil {
+		s.writeError(w, true, parseStatus(err), fmt.Sprint("invalid request: ", err))
+		return
+	}
+
+	colony, err := lemin.Parse(strings.NewReader(request.Colony))
+	if err != nil {
+		s.writeError(w, true, http.StatusBadRequest, fmt.Sprint("invalid data format: ", err))
+		return
+	}
+	if colony.NumAnts > s.maxAnts {
+		s.writeError(w, true, http.StatusUnprocessableEntity, s.tooManyAnts(colony))
+		return
+	}
+	turns, err := lemin.ReadMoves(strings.NewReader(request.Moves))
+	if err != nil {
+		s.writeError(w, true, http.StatusBadRequest, fmt.Sprint("invalid move log: ", err))
+		return
+	}
+
+	// A broken rule is a valid answer, not a failed request
+	response := verifyResponse{Valid: true, Turns: len(turns)}
+	if err := lemin.Verify(colony, turns); err != nil {
+		response = verifyResponse{Error: err.Error()}
+		var moveErr *lemin.MoveError
+		if errors.As(err, &moveErr) {
+			response.Turn = moveErr.Turn
+			response.Ant = moveErr.Ant
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Colonies with more ants than the limit are rejected before anything is allocated for the ants
+func (s *server) tooManyAnts(colony *lemin.Colony) string {
+	return fmt.Sprintf("%d ants, the server accepts at most %d", colony.NumAnts, s.maxAnts)
+}
+
+// Request bodies over the limit get 413, everything else that cannot be read is the client's fault
+func parseStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// Writing an error in the same format the client asked the answer in
+func (s *server) writeError(w http.ResponseWriter, asJSON bool, status int, msg string) {
+	if asJSON {
+		writeJSON(w, status, map[string]string{"error": msg})
+		return
+	}
+	http.Error(w, "ERROR: "+msg, status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
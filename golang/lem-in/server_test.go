@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandleSolve(t *testing.T) {
+	colony, err := os.ReadFile(filepath.Join("examples", "example05.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		body     string
+		status   int
+		contains string
+	}{
+		{"solved", 1 << 20, string(colony), http.StatusOK, "Turn 1: "},
+		{"limit in the middle of a line", 200, string(colony), http.StatusRequestEntityTooLarge, "request body over 200 bytes"},
+		{"invalid colony", 1 << 20, "0\n", http.StatusBadRequest, "invalid number of ants '0'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &server{maxBytes: test.maxBytes, maxAnts: 1000, timeout: 10 * time.Second}
+			recorder := httptest.NewRecorder()
+			s.routes().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/solve", strings.NewReader(test.body)))
+			if recorder.Code != test.status {
+				t.Errorf("got status %d, want %d: %s", recorder.Code, test.status, recorder.Body)
+			}
+			if !strings.Contains(recorder.Body.String(), test.contains) {
+				t.Errorf("body %q does not contain %q", recorder.Body, test.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"lemin/lemin"
+)
+
+// Print the statistics of the solution
+func PrintStats(w io.Writer, stats *lemin.Stats) {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Disjoint paths found: %d\n", stats.DisjointPaths)
+	fmt.Fprintf(w, "Paths used: %d\n", len(stats.Paths))
+	for i, path := range stats.Paths {
+		fmt.Fprintf(w, "Path %d: length %d, %d ants: %s\n", i+1, stats.PathLengths[i], stats.AntsPerPath[i], strings.Join(path, "-"))
+	}
+	fmt.Fprintf(w, "Turns: %d\n", stats.Turns)
+
+	if stats.Suboptimal {
+		fmt.Fprintln(w, "Lower bound: unknown, the search stopped at the timeout and the answer may be suboptimal")
+	} else if stats.Turns <= stats.LowerBound {
+		fmt.Fprintf(w, "Lower bound: %d (optimal)\n", stats.LowerBound)
+	} else {
+		fmt.Fprintf(w, "Lower bound: %d (%d turns over)\n", stats.LowerBound, stats.Turns-stats.LowerBound)
+	}
+}
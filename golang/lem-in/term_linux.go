@@ -0,0 +1,49 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Switching the terminal to raw mode so single key presses can be read without Enter.
+// Returns a function that restores the previous mode.
+func makeRaw(tty *os.File) (func(), error) {
+	var old syscall.Termios
+	if err := ioctl(tty, syscall.TCGETS, unsafe.Pointer(&old)); err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO // No line buffering and no echo
+	raw.Lflag &^= syscall.ISIG                  // Ctrl+C is read as byte 3 instead of killing the program before the terminal is restored
+	raw.Cc[syscall.VMIN] = 1                    // Reading returns after every byte
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(tty, syscall.TCSETS, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		ioctl(tty, syscall.TCSETS, unsafe.Pointer(&old))
+	}, nil
+}
+
+// Getting the terminal size in characters
+func terminalSize(tty *os.File) (int, int, error) {
+	var size struct {
+		Rows, Cols, X, Y uint16
+	}
+	if err := ioctl(tty, syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, err
+	}
+	return int(size.Cols), int(size.Rows), nil
+}
+
+func ioctl(tty *os.File, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, tty.Fd(), request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
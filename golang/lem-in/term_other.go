@@ -0,0 +1,18 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// Raw mode is only implemented for Linux, other systems read the keys line by line (key + Enter)
+func makeRaw(tty *os.File) (func(), error) {
+	return func() {}, nil
+}
+
+// Terminal size is unknown, the default size is used
+func terminalSize(tty *os.File) (int, int, error) {
+	return 0, 0, errors.New("terminal size not supported")
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"lemin/lemin"
+)
+
+// ANSI escape sequences
+const (
+	ansiClear      = "\x1b[2J\x1b[H"
+	ansiHome       = "\x1b[H"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiReset      = "\x1b[0m"
+	ansiGreen      = "\x1b[1;32m"
+	ansiRed        = "\x1b[1;31m"
+	ansiYellow     = "\x1b[1;33m"
+	ansiGrey       = "\x1b[90m"
+)
+
+// Keyboard actions of the visualizer
+const (
+	keyNext = iota
+	keyPrev
+	keyPlay
+	keyFirst
+	keyLast
+	keyQuit
+)
+
+// Character cell of the drawing
+type tuiCell struct {
+	char  rune
+	color string
+}
+
+// Terminal visualizer state
+type tui struct {
+	data     *lemin.Colony
+	solution []string
+	states   []map[int]string // Room of every ant that has left the start, after every turn
+	position map[string][2]int
+	width    int
+	height   int
+	current  int
+	playing  bool
+}
+
+// Running the interactive terminal visualizer on the controlling terminal
+func runTUI(data *lemin.Colony, solution []string) error {
+	// Using the terminal directly so the colony can still come from the standard input
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	restore, err := makeRaw(tty)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	width, height, err := terminalSize(tty)
+	if err != nil || width < 20 || height < 8 {
+		width, height = 80, 24
+	}
+
+	t := &tui{data: data, solution: solution, width: width, height: height}
+	t.buildStates()
+	t.placeRooms()
+
+	out := bufio.NewWriter(tty)
+	fmt.Fprint(out, ansiHideCursor+ansiClear)
+	defer func() {
+		fmt.Fprint(out, ansiReset+ansiShowCursor+ansiClear)
+		out.Flush()
+	}()
+
+	keys := make(chan int)
+	go readKeys(tty, keys)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		t.draw(out)
+		out.Flush()
+
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch key {
+			case keyNext:
+				t.playing = false
+				t.step(1)
+			case keyPrev:
+				t.playing = false
+				t.step(-1)
+			case keyFirst:
+				t.playing = false
+				t.current = 0
+			case keyLast:
+				t.playing = false
+				t.current = len(t.states) - 1
+			case keyPlay:
+				t.playing = !t.playing
+				if t.playing && t.current == len(t.states)-1 {
+					t.current = 0
+				}
+			case keyQuit:
+				return nil
+			}
+		case <-ticker.C:
+			if t.playing {
+				t.step(1)
+				if t.current == len(t.states)-1 {
+					t.playing = false
+				}
+			}
+		}
+	}
+}
+
+// Reading key presses and sending them as actions, arrow keys arrive as escape sequences
+func readKeys(tty *os.File, keys chan<- int) {
+	defer close(keys)
+	reader := bufio.NewReader(tty)
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		switch b {
+		case 'n', 'l', '\n':
+			keys <- keyNext
+		case 'p', 'h':
+			keys <- keyPrev
+		case ' ':
+			keys <- keyPlay
+		case 'g':
+			keys <- keyFirst
+		case 'G':
+			keys <- keyLast
+		case 'q', 3: // 3 is Ctrl+C in raw mode
+			keys <- keyQuit
+			return
+		case 0x1b: // Escape sequence: ESC [ C is right arrow and ESC [ D is left arrow
+			if next, _ := reader.ReadByte(); next != '[' {
+				continue
+			}
+			switch arrow, _ := reader.ReadByte(); arrow {
+			case 'C':
+				keys <- keyNext
+			case 'D':
+				keys <- keyPrev
+			}
+		}
+	}
+}
+
+// Moving the given number of turns, staying inside the simulation
+func (t *tui) step(turns int) {
+	t.current += turns
+	if t.current < 0 {
+		t.current = 0
+	}
+	if t.current > len(t.states)-1 {
+		t.current = len(t.states) - 1
+	}
+}
+
+// Replaying the moves to know where every ant is after every turn
+func (t *tui) buildStates() {
+	state := make(map[int]string)
+	t.states = []map[int]string{state}
+	turns, _ := lemin.SplitTurns(t.solution)
+	for _, turn := range turns {
+		next := make(map[int]string, len(state))
+		for ant, room := range state {
+			next[ant] = room
+		}
+		for _, move := range turn {
+			next[move.Ant] = move.Room
+		}
+		t.states = append(t.states, next)
+		state = next
+	}
+}
+
+// Scaling the room coordinates to the character grid, leaving room for the status lines
+func (t *tui) placeRooms() {
+	minX, minY, maxX, maxY := 0, 0, 0, 0
+	first := true
+	for _, room := range t.data.Rooms {
+		if first || room.X < minX {
+			minX = room.X
+		}
+		if first || room.Y < minY {
+			minY = room.Y
+		}
+		if first || room.X > maxX {
+			maxX = room.X
+		}
+		if first || room.Y > maxY {
+			maxY = room.Y
+		}
+		first = false
+	}
+
+	cols, rows := t.width-3, t.height-4
+	scaleX, scaleY := 8.0, 4.0 // Largest gap between neighbour coordinates, characters are about twice as high as wide
+	if maxX > minX && float64(cols-1)/float64(maxX-minX) < scaleX {
+		scaleX = float64(cols-1) / float64(maxX-minX)
+	}
+	if maxY > minY && float64(rows-1)/float64(maxY-minY) < scaleY {
+		scaleY = float64(rows-1) / float64(maxY-minY)
+	}
+
+	t.position = make(map[string][2]int, len(t.data.Rooms))
+	for name, room := range t.data.Rooms {
+		x := int(float64(room.X-minX)*scaleX + 0.5)
+		y := int(float64(room.Y-minY)*scaleY + 0.5)
+		t.position[name] = [2]int{x + 1, y}
+	}
+}
+
+// Drawing the colony with the ants of the current turn and the status lines
+func (t *tui) draw(out *bufio.Writer) {
+	rows := t.height - 4
+	grid := make([][]tuiCell, rows)
+	for y := range grid {
+		grid[y] = make([]tuiCell, t.width-1) // The last column is left empty so the terminal does not wrap
+		for x := range grid[y] {
+			grid[y][x] = tuiCell{char: ' '}
+		}
+	}
+	set := func(x, y int, char rune, color string) {
+		if y >= 0 && y < rows && x >= 0 && x < t.width-1 {
+			grid[y][x] = tuiCell{char: char, color: color}
+		}
+	}
+
+	// Tunnels first so the rooms are drawn on top of them
+	for room, linkedRooms := range t.data.Tunnels {
+		for _, linked := range linkedRooms {
+			if room < linked {
+				from, to := t.position[room], t.position[linked]
+				drawLine(from[0], from[1], to[0], to[1], func(x, y int, char rune) {
+					set(x, y, char, ansiGrey)
+				})
+			}
+		}
+	}
+
+	// Rooms with the ants in them
+	occupied := make(map[string]int)
+	for ant, room := range t.states[t.current] {
+		occupied[room] = ant
+	}
+	atEnd := 0
+	for _, room := range t.states[t.current] {
+		if t.data.IsEnd(room) {
+			atEnd++
+		}
+	}
+	for name, pos := range t.position {
+		switch {
+		case t.data.IsStart(name):
+			set(pos[0], pos[1], 'S', ansiGreen)
+		case t.data.IsEnd(name):
+			set(pos[0], pos[1], 'E', ansiRed)
+		case occupied[name] != 0:
+			set(pos[0], pos[1], '@', ansiYellow)
+		default:
+			set(pos[0], pos[1], 'o', "")
+		}
+	}
+
+	fmt.Fprint(out, ansiHome)
+	for _, row := range grid {
+		color := ""
+		for _, cell := range row {
+			if cell.color != color {
+				fmt.Fprint(out, ansiReset+cell.color)
+				color = cell.color
+			}
+			out.WriteRune(cell.char)
+		}
+		fmt.Fprint(out, ansiReset+"\r\n")
+	}
+
+	// Status lines
+	moves := ""
+	if t.current > 0 {
+		moves = t.solution[t.current-1]
+	}
+	state := "paused"
+	if t.playing {
+		state = "playing"
+	}
+	atStart := t.data.NumAnts - len(t.states[t.current])
+	status := []string{
+		fmt.Sprintf("Turn %d/%d (%s)  start: %d ants  end: %d ants", t.current, len(t.states)-1, state, atStart, atEnd),
+		moves,
+		"n/→ next  p/← previous  space play/pause  g/G first/last  q quit",
+	}
+	for _, line := range status {
+		if runes := []rune(line); len(runes) > t.width-1 {
+			line = string(runes[:t.width-1])
+		}
+		fmt.Fprint(out, "\x1b[2K"+line+"\r\n")
+	}
+}
+
+// Drawing a straight line between two cells (Bresenham) with a character that follows the direction
+func drawLine(x0, y0, x1, y1 int, plot func(x, y int, char rune)) {
+	var char rune
+	switch dx, dy := x1-x0, y1-y0; {
+	case dy == 0:
+		char = '-'
+	case dx == 0:
+		char = '|'
+	case (dx > 0) == (dy > 0):
+		char = '\\'
+	default:
+		char = '/'
+	}
+
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		plot(x0, y0, char)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"lemin/lemin"
+)
+
+// Running the verify subcommand: replaying a move log against the colony
+func runVerify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	multi := flags.Bool("multi", false, "allow several ##start and ##end rooms")
+	flags.Parse(args)
+	args = flags.Args()
+	if len(args) != 2 {
+		Exit("Usage: 'go run . verify [-multi] [colony] [moves]' (use '-' to read one of them from standard input)")
+	}
+
+	source, err := openColony(args[0])
+	if err != nil {
+		Exit(fmt.Sprint("Error reading the colony: ", err))
+	}
+	defer source.Close()
+	colony, err := lemin.ParseWithOptions(source.Reader(), lemin.ParseOptions{Multi: *multi})
+	if err != nil {
+		Exit(fmt.Sprint("ERROR: invalid data format: ", err))
+	}
+
+	moves, err := openColony(args[1])
+	if err != nil {
+		Exit(fmt.Sprint("Error reading the moves: ", err))
+	}
+	defer moves.Close()
+	turns, err := lemin.ReadMoves(moves.Reader())
+	if err != nil {
+		Exit(fmt.Sprint("ERROR: invalid move log: ", err))
+	}
+
+	if err := lemin.Verify(colony, turns); err != nil {
+		Exit(fmt.Sprint("ERROR: invalid move: ", err))
+	}
+	fmt.Printf("OK: %d ants reached the end in %d turns\n", colony.NumAnts, len(turns))
+}